@@ -0,0 +1,17 @@
+package cache
+
+// Memoize returns a function that caches the result of fn for each key.
+//
+// The returned function is not safe for concurrent use. fn should be pure,
+// as it is only called once per distinct key.
+func Memoize[K comparable, V any](fn func(K) V) func(K) V {
+	results := make(map[K]V)
+	return func(key K) V {
+		if value, exists := results[key]; exists {
+			return value
+		}
+		value := fn(key)
+		results[key] = value
+		return value
+	}
+}
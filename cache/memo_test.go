@@ -0,0 +1,42 @@
+package cache
+
+import "testing"
+
+func TestMemoize(t *testing.T) {
+	calls := make(map[int]int)
+	square := Memoize(func(v int) int {
+		calls[v]++
+		return v * v
+	})
+
+	for range 3 {
+		for _, v := range []int{0, 2, 3, 2} {
+			if got := square(v); got != v*v {
+				t.Fatalf("square(%d) = %d, want %d", v, got, v*v)
+			}
+		}
+	}
+
+	// Zero results are cached like any other value.
+	for _, v := range []int{0, 2, 3} {
+		if calls[v] != 1 {
+			t.Errorf("fn called %d times for key %d, want 1", calls[v], v)
+		}
+	}
+}
+
+func TestMemoizeIndependentCaches(t *testing.T) {
+	calls := 0
+	fn := func(s string) string {
+		calls++
+		return ""
+	}
+
+	a, b := Memoize(fn), Memoize(fn)
+	a("x")
+	a("x")
+	b("x")
+	if calls != 2 {
+		t.Fatalf("fn called %d times, want 2 (once per memoized function)", calls)
+	}
+}
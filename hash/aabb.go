@@ -0,0 +1,31 @@
+package hash
+
+// AABB is an axis-aligned bounding box. All edges are inclusive.
+type AABB struct {
+	MinX, MinY, MaxX, MaxY float32
+}
+
+// RegionAABB converts a [minX, minY, maxX, maxY] region, as used by Grid, to an AABB.
+func RegionAABB(region [4]float32) AABB {
+	return AABB{MinX: region[0], MinY: region[1], MaxX: region[2], MaxY: region[3]}
+}
+
+// Region returns the box as a [minX, minY, maxX, maxY] region, as used by Grid.
+func (a AABB) Region() [4]float32 {
+	return [4]float32{a.MinX, a.MinY, a.MaxX, a.MaxY}
+}
+
+// Intersects reports whether the two boxes overlap. Boxes that only touch along an edge intersect.
+func (a AABB) Intersects(other AABB) bool {
+	return a.MinX <= other.MaxX && a.MaxX >= other.MinX && a.MinY <= other.MaxY && a.MaxY >= other.MinY
+}
+
+// Contains reports whether the point (x, y) lies inside the box or on its edge.
+func (a AABB) Contains(x, y float32) bool {
+	return x >= a.MinX && x <= a.MaxX && y >= a.MinY && y <= a.MaxY
+}
+
+// Center returns the midpoint of the box.
+func (a AABB) Center() (float32, float32) {
+	return (a.MinX + a.MaxX) / 2, (a.MinY + a.MaxY) / 2
+}
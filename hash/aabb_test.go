@@ -0,0 +1,45 @@
+package hash
+
+import "testing"
+
+func TestAABBIntersects(t *testing.T) {
+	a := AABB{0, 0, 10, 10}
+	cases := []struct {
+		name  string
+		other AABB
+		want  bool
+	}{
+		{"overlapping", AABB{5, 5, 15, 15}, true},
+		{"contained", AABB{2, 2, 4, 4}, true},
+		{"touching edge", AABB{10, 0, 20, 10}, true},
+		{"touching corner", AABB{10, 10, 20, 20}, true},
+		{"separate on x", AABB{11, 0, 20, 10}, false},
+		{"separate on y", AABB{0, -20, 10, -1}, false},
+	}
+	for _, c := range cases {
+		if got := a.Intersects(c.other); got != c.want {
+			t.Errorf("%s: Intersects(%v) = %v, want %v", c.name, c.other, got, c.want)
+		}
+		if got := c.other.Intersects(a); got != c.want {
+			t.Errorf("%s: Intersects is not symmetric for %v", c.name, c.other)
+		}
+	}
+}
+
+func TestAABBContainsAndCenter(t *testing.T) {
+	a := AABB{-4, 2, 4, 6}
+	for _, p := range [][2]float32{{0, 4}, {-4, 2}, {4, 6}} {
+		if !a.Contains(p[0], p[1]) {
+			t.Errorf("Contains(%v, %v) = false, want true", p[0], p[1])
+		}
+	}
+	if a.Contains(4.01, 4) || a.Contains(0, 1.99) {
+		t.Error("Contains reported a point outside the box")
+	}
+	if x, y := a.Center(); x != 0 || y != 4 {
+		t.Errorf("Center() = (%v, %v), want (0, 4)", x, y)
+	}
+	if got := RegionAABB(a.Region()); got != a {
+		t.Errorf("RegionAABB(Region()) = %v, want %v", got, a)
+	}
+}
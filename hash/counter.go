@@ -0,0 +1,74 @@
+package hash
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Counter is a multiset that tracks how many times each item was added.
+type Counter[T comparable] map[T]int
+
+func NewCounter[T comparable](size ...int) Counter[T] {
+	sz := 0
+	if len(size) > 0 {
+		sz = size[0]
+	}
+	counter := make(Counter[T], sz)
+	return counter
+}
+
+func (c *Counter[T]) Add(item T) {
+	(*c)[item]++
+}
+
+// AddN adds n occurrences of item. Non-positive n is a no-op.
+func (c *Counter[T]) AddN(item T, n int) {
+	if n <= 0 {
+		return
+	}
+	(*c)[item] += n
+}
+
+func (c *Counter[T]) Count(item T) int {
+	return (*c)[item]
+}
+
+// Remove removes one occurrence of item, deleting it once its count reaches zero.
+func (c *Counter[T]) Remove(item T) {
+	n, exists := (*c)[item]
+	if !exists {
+		return
+	}
+	if n <= 1 {
+		delete(*c, item)
+		return
+	}
+	(*c)[item] = n - 1
+}
+
+// Total returns the sum of all counts.
+func (c *Counter[T]) Total() int {
+	total := 0
+	for _, n := range *c {
+		total += n
+	}
+	return total
+}
+
+// MostCommon returns up to k items ordered by descending count.
+// The order of items with equal counts is unspecified.
+func (c *Counter[T]) MostCommon(k int) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	items := make([]T, 0, len(*c))
+	for item := range *c {
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		return cmp.Compare((*c)[b], (*c)[a])
+	})
+
+	return items[:min(k, len(items))]
+}
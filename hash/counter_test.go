@@ -0,0 +1,47 @@
+package hash
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCounterRemoveToZero(t *testing.T) {
+	c := NewCounter[string]()
+	c.AddN("a", 2)
+	c.Add("b")
+
+	c.Remove("a")
+	if got := c.Count("a"); got != 1 {
+		t.Fatalf("Count(a) = %d, want 1", got)
+	}
+
+	c.Remove("a")
+	c.Remove("b")
+	if len(c) != 0 {
+		t.Fatalf("expected empty counter after decrementing to zero, got %v", c)
+	}
+
+	// Removing an absent item must not leave a negative entry.
+	c.Remove("missing")
+	if _, exists := c["missing"]; exists {
+		t.Fatal("Remove created an entry for a missing item")
+	}
+}
+
+func TestCounterTotalAndMostCommon(t *testing.T) {
+	c := NewCounter[string]()
+	c.AddN("a", 3)
+	c.AddN("b", 5)
+	c.Add("c")
+	c.AddN("d", 0)
+
+	if got := c.Total(); got != 9 {
+		t.Fatalf("Total() = %d, want 9", got)
+	}
+	if got, want := c.MostCommon(2), []string{"b", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("MostCommon(2) = %v, want %v", got, want)
+	}
+	if got := c.MostCommon(10); len(got) != 3 {
+		t.Fatalf("MostCommon(10) returned %d items, want 3", len(got))
+	}
+}
@@ -1,6 +1,9 @@
 package hash
 
-import "math"
+import (
+	"math"
+	"slices"
+)
 
 func EncodeGridKey(x, y int32) uint64 {
 	const offset = 1 << 31
@@ -9,6 +12,15 @@ func EncodeGridKey(x, y int32) uint64 {
 	return (upper << 32) | lower
 }
 
+// EncodeGridKeyChecked is like EncodeGridKey but takes wider cell indices and
+// returns false if either index falls outside the int32 range a key can hold.
+func EncodeGridKeyChecked(x, y int64) (uint64, bool) {
+	if x < math.MinInt32 || x > math.MaxInt32 || y < math.MinInt32 || y > math.MaxInt32 {
+		return 0, false
+	}
+	return EncodeGridKey(int32(x), int32(y)), true
+}
+
 func DecodeGridKey(key uint64) (x, y int32) {
 	const offset = 1 << 31
 	upper := int32(uint32(key >> 32))
@@ -25,11 +37,28 @@ const (
 	GridCellPadding GridItemPadding = 1
 )
 
+// GridEdgeMode controls which cells a region's max edge falls into.
+type GridEdgeMode uint8
+
+const (
+	// GridEdgeExclusive treats the max edge as exclusive, so a region exactly
+	// filling one cell occupies only that cell. Degenerate regions lying on a
+	// cell boundary still occupy the cell they start in.
+	GridEdgeExclusive GridEdgeMode = 0
+	// GridEdgeInclusive treats the max edge as inclusive, so a region whose max
+	// edge lies on a cell boundary also occupies the neighbouring cell.
+	GridEdgeInclusive GridEdgeMode = 1
+)
+
 type GridInsertionFunc[T comparable] func(minX, minY, maxX, maxY float32) bool
 
 // Grid is a simple spatial hash grid that stores items in cells based on their coordinates.
 //
 // Use this for static or infrequently-updated items. It is a minimalist implementation.
+//
+// Items within a cell are kept in the order they entered that cell, and removals
+// preserve the relative order of the remaining items, so cell iteration is
+// reproducible for the same sequence of operations.
 type Grid[T comparable] struct {
 	gen        uint64
 	cellWidth  float32
@@ -37,7 +66,11 @@ type Grid[T comparable] struct {
 	cells      map[uint64][]T
 	items      map[T]uint64
 	itemCells  map[T][]uint64
+	itemBounds map[T]AABB
 	qBuf       []T
+	kBuf       []uint64
+	cellHint   int
+	edgeMode   GridEdgeMode
 }
 
 func NewGrid[T comparable](cellWidth, cellHeight float32) *Grid[T] {
@@ -47,22 +80,53 @@ func NewGrid[T comparable](cellWidth, cellHeight float32) *Grid[T] {
 		cells:      make(map[uint64][]T),
 		items:      make(map[T]uint64),
 		itemCells:  make(map[T][]uint64),
+		itemBounds: make(map[T]AABB),
+	}
+}
+
+// NewGridWithCapacity is like NewGrid but pre-sizes the grid's maps for expectedItems items.
+func NewGridWithCapacity[T comparable](cellWidth, cellHeight float32, expectedItems int) *Grid[T] {
+	return &Grid[T]{
+		cellWidth:  cellWidth,
+		cellHeight: cellHeight,
+		cells:      make(map[uint64][]T, expectedItems),
+		items:      make(map[T]uint64, expectedItems),
+		itemCells:  make(map[T][]uint64, expectedItems),
+		itemBounds: make(map[T]AABB, expectedItems),
 	}
 }
 
+// NewGridWithHint is like NewGrid but pre-allocates each new cell with room for
+// expectedPerCell items, reducing slice growth in dense grids.
+func NewGridWithHint[T comparable](cellWidth, cellHeight float32, expectedPerCell int) *Grid[T] {
+	g := NewGrid[T](cellWidth, cellHeight)
+	g.cellHint = expectedPerCell
+	return g
+}
+
 func (g *Grid[T]) cellRange(minX, minY, maxX, maxY float32) (minCellX, minCellY, maxCellX, maxCellY int32) {
 	minCellX = int32(math.Floor(float64(minX / g.cellWidth)))
 	minCellY = int32(math.Floor(float64(minY / g.cellHeight)))
+	if g.edgeMode == GridEdgeInclusive {
+		maxCellX = int32(math.Floor(float64(maxX/g.cellWidth))) + 1
+		maxCellY = int32(math.Floor(float64(maxY/g.cellHeight))) + 1
+		return
+	}
 	maxCellX = int32(math.Ceil(float64(maxX / g.cellWidth)))
 	maxCellY = int32(math.Ceil(float64(maxY / g.cellHeight)))
-	return
-}
 
-func (g *Grid[T]) insert(item T, region [4]float32, padding GridItemPadding, fn GridInsertionFunc[T]) bool {
-	if g.Contains(item) {
-		return false
+	// A degenerate region on a cell boundary still occupies the cell it starts in.
+	if maxX == minX {
+		maxCellX = minCellX + 1
 	}
+	if maxY == minY {
+		maxCellY = minCellY + 1
+	}
+	return
+}
 
+// appendCellKeys appends the keys of the cells covered by region to dst.
+func (g *Grid[T]) appendCellKeys(dst []uint64, region [4]float32, padding GridItemPadding, fn GridInsertionFunc[T]) []uint64 {
 	minCellX, minCellY, maxCellX, maxCellY := g.cellRange(region[0], region[1], region[2], region[3])
 
 	if padding == GridCellPadding {
@@ -72,7 +136,6 @@ func (g *Grid[T]) insert(item T, region [4]float32, padding GridItemPadding, fn
 		maxCellY++
 	}
 
-	var cellKeys []uint64
 	for cy := minCellY; cy < maxCellY; cy++ {
 		for cx := minCellX; cx < maxCellX; cx++ {
 			doInsert := true
@@ -84,15 +147,27 @@ func (g *Grid[T]) insert(item T, region [4]float32, padding GridItemPadding, fn
 				doInsert = fn(cellMinX, cellMinY, cellMaxX, cellMaxY)
 			}
 			if doInsert {
-				key := EncodeGridKey(cx, cy)
-				g.cells[key] = append(g.cells[key], item)
-				cellKeys = append(cellKeys, key)
+				dst = append(dst, EncodeGridKey(cx, cy))
 			}
 		}
 	}
 
+	return dst
+}
+
+func (g *Grid[T]) insert(item T, region [4]float32, padding GridItemPadding, fn GridInsertionFunc[T]) bool {
+	if g.Contains(item) {
+		return false
+	}
+
+	cellKeys := g.appendCellKeys(nil, region, padding, fn)
+	for _, key := range cellKeys {
+		g.addToCell(key, item)
+	}
+
 	g.items[item] = 0
 	g.itemCells[item] = cellKeys
+	g.itemBounds[item] = RegionAABB(region)
 
 	return true
 }
@@ -119,10 +194,36 @@ func (g *Grid[T]) Cells() []uint64 {
 	return keys
 }
 
+// CellsSorted is like Cells but returns the keys in ascending order.
+func (g *Grid[T]) CellsSorted() []uint64 {
+	keys := g.Cells()
+	slices.Sort(keys)
+	return keys
+}
+
+// CellCount returns the number of items stored in the cell with the given key.
+func (g *Grid[T]) CellCount(key uint64) int {
+	return len(g.cells[key])
+}
+
+// CellCounts returns the number of items stored in each occupied cell.
+func (g *Grid[T]) CellCounts() map[uint64]int {
+	counts := make(map[uint64]int, len(g.cells))
+	for key, items := range g.cells {
+		counts[key] = len(items)
+	}
+	return counts
+}
+
 func (g *Grid[T]) CellSize() (cellWidth, cellHeight float32) {
 	return g.cellWidth, g.cellHeight
 }
 
+// Len returns the number of items in the grid.
+func (g *Grid[T]) Len() int {
+	return len(g.items)
+}
+
 // ForEach calls the given function for each item in the grid.
 func (g *Grid[T]) ForEach(fn func(item T)) {
 	for item := range g.items {
@@ -130,15 +231,57 @@ func (g *Grid[T]) ForEach(fn func(item T)) {
 	}
 }
 
+// ForEachInCell calls the given function for each item stored in the cell with the given key.
+func (g *Grid[T]) ForEachInCell(key uint64, fn func(item T)) {
+	for _, item := range g.cells[key] {
+		fn(item)
+	}
+}
+
+// ForEachCell calls the given function for each occupied cell.
+//
+// Items spanning multiple cells are seen once per cell. The items slice is
+// owned by the grid and must not be modified or retained.
+func (g *Grid[T]) ForEachCell(fn func(key uint64, items []T)) {
+	for key, items := range g.cells {
+		fn(key, items)
+	}
+}
+
 // Clear removes all items from the grid.
 func (g *Grid[T]) Clear() {
 	clear(g.cells)
 	clear(g.items)
 	clear(g.itemCells)
+	clear(g.itemBounds)
 	clear(g.qBuf)
 	g.gen = 0
 }
 
+// Compact reclaims memory from cells whose backing arrays are much larger than
+// their contents and deletes any empty cells.
+func (g *Grid[T]) Compact() {
+	for key, items := range g.cells {
+		if len(items) == 0 {
+			delete(g.cells, key)
+			continue
+		}
+		if cap(items) > max(2*len(items), g.cellHint) {
+			g.cells[key] = append(make([]T, 0, max(len(items), g.cellHint)), items...)
+		}
+	}
+}
+
+// ShrinkQueryBuffer releases the internal query buffer, which otherwise keeps the
+// capacity of the largest query ever made.
+//
+// The next query reallocates the buffer, so avoid calling this when large queries
+// are routine.
+func (g *Grid[T]) ShrinkQueryBuffer() {
+	g.qBuf = nil
+	g.kBuf = nil
+}
+
 // Resize changes the cell size of the grid, clearing all existing items.
 // If the size is unchanged, no action is taken.
 //
@@ -153,6 +296,24 @@ func (g *Grid[T]) Resize(cellWidth, cellHeight float32) {
 	g.cellHeight = cellHeight
 }
 
+// SetEdgeMode changes how region max edges map to cells, clearing all existing items.
+// If the mode is unchanged, no action is taken.
+//
+// WARNING: This will remove all existing items in the grid.
+func (g *Grid[T]) SetEdgeMode(mode GridEdgeMode) {
+	if g.edgeMode == mode {
+		return
+	}
+	g.Clear()
+
+	g.edgeMode = mode
+}
+
+// EdgeMode returns how region max edges map to cells.
+func (g *Grid[T]) EdgeMode() GridEdgeMode {
+	return g.edgeMode
+}
+
 // Contains checks if the item is already in the grid.
 func (g *Grid[T]) Contains(item T) bool {
 	_, exists := g.items[item]
@@ -180,14 +341,66 @@ func (g *Grid[T]) Remove(item T) {
 	cellKeys := g.itemCells[item]
 	delete(g.items, item)
 	delete(g.itemCells, item)
+	delete(g.itemBounds, item)
 
 	for _, key := range cellKeys {
+		g.removeFromCell(key, item)
+	}
+}
+
+// RemoveFunc removes every item for which pred returns true and returns the number removed.
+//
+// Each affected cell is compacted once, which is faster than calling Remove in a loop
+// when removing many items.
+func (g *Grid[T]) RemoveFunc(pred func(item T) bool) int {
+	// Matching items are stamped with a fresh generation so cells can be
+	// compacted without a separate lookup set.
+	g.nextGen()
+
+	var marked []T
+	for item := range g.items {
+		if pred(item) {
+			g.items[item] = g.gen
+			marked = append(marked, item)
+		}
+	}
+	g.removeMarked(marked)
+
+	return len(marked)
+}
+
+// RemoveInRegion removes every item that intersects the given AABB and returns the number removed.
+//
+// Items are removed from all of their cells, including those outside the region.
+func (g *Grid[T]) RemoveInRegion(region [4]float32) int {
+	// QueryInto stamps every intersecting item with the current generation. A local
+	// buffer keeps results previously returned from the shared query buffer intact.
+	marked := g.QueryInto(nil, region)
+	g.removeMarked(marked)
+
+	return len(marked)
+}
+
+// removeMarked removes the given items, which must all be stamped with the
+// current generation, compacting each affected cell once.
+func (g *Grid[T]) removeMarked(marked []T) {
+	if len(marked) == 0 {
+		return
+	}
+
+	affected := make(map[uint64]struct{})
+	for _, item := range marked {
+		for _, key := range g.itemCells[item] {
+			affected[key] = struct{}{}
+		}
+	}
+
+	for key := range affected {
 		items := g.cells[key]
 
-		// compact in-place, keeping only elements != item
 		j := 0
 		for _, it := range items {
-			if it != item {
+			if g.items[it] != g.gen {
 				items[j] = it
 				j++
 			}
@@ -199,28 +412,330 @@ func (g *Grid[T]) Remove(item T) {
 			g.cells[key] = items[:j]
 		}
 	}
+
+	for _, item := range marked {
+		delete(g.items, item)
+		delete(g.itemCells, item)
+		delete(g.itemBounds, item)
+	}
+}
+
+// addToCell appends item to a single cell, creating the cell if needed.
+func (g *Grid[T]) addToCell(key uint64, item T) {
+	items, exists := g.cells[key]
+	if !exists && g.cellHint > 0 {
+		items = make([]T, 0, g.cellHint)
+	}
+	g.cells[key] = append(items, item)
+}
+
+// removeFromCell removes item from a single cell, deleting the cell if it becomes empty.
+func (g *Grid[T]) removeFromCell(key uint64, item T) {
+	items := g.cells[key]
+
+	// compact in-place, keeping only elements != item; order is preserved
+	j := 0
+	for _, it := range items {
+		if it != item {
+			items[j] = it
+			j++
+		}
+	}
+
+	if j == 0 {
+		delete(g.cells, key)
+	} else {
+		g.cells[key] = items[:j]
+	}
+}
+
+// Update moves an item to a new region, only touching cells that were vacated or newly entered.
+// Returns false if the item is not in the grid.
+//
+// The new cells are computed without a GridInsertionFunc, so an item added with
+// InsertFunc occupies every cell of its new region; re-insert it to keep a filter.
+func (g *Grid[T]) Update(item T, region [4]float32, padding GridItemPadding) bool {
+	oldKeys, exists := g.itemCells[item]
+	if !exists {
+		return false
+	}
+
+	g.kBuf = g.appendCellKeys(g.kBuf[:0], region, padding, nil)
+
+	for _, key := range oldKeys {
+		if !slices.Contains(g.kBuf, key) {
+			g.removeFromCell(key, item)
+		}
+	}
+	for _, key := range g.kBuf {
+		if !slices.Contains(oldKeys, key) {
+			g.addToCell(key, item)
+		}
+	}
+
+	g.itemCells[item] = append(oldKeys[:0], g.kBuf...)
+	g.itemBounds[item] = RegionAABB(region)
+
+	return true
+}
+
+// MoveTo places an item at a new region, inserting it if it is not already in the grid.
+// Returns whether the item was present before the call.
+//
+// Like Update, it does not apply a GridInsertionFunc to the new region.
+func (g *Grid[T]) MoveTo(item T, region [4]float32, padding GridItemPadding) bool {
+	if g.Update(item, region, padding) {
+		return true
+	}
+	g.insert(item, region, padding, nil)
+	return false
 }
 
 // Query returns all items that intersect the given AABB.
+//
+// The returned slice aliases an internal buffer shared by all query methods and
+// is only valid until the next query. Use QueryInto to keep the results.
 func (g *Grid[T]) Query(region [4]float32) []T {
-	g.qBuf = g.qBuf[:0]
-	g.gen++
+	g.qBuf = g.QueryInto(g.qBuf[:0], region)
+	return g.qBuf
+}
+
+// QueryInto appends all items that intersect the given AABB to dst and returns the extended slice.
+func (g *Grid[T]) QueryInto(dst []T, region [4]float32) []T {
+	g.nextGen()
 
 	minCellX, minCellY, maxCellX, maxCellY := g.cellRange(region[0], region[1], region[2], region[3])
 	for cy := minCellY; cy < maxCellY; cy++ {
 		for cx := minCellX; cx < maxCellX; cx++ {
-			if items, exists := g.cells[EncodeGridKey(cx, cy)]; exists {
-				for _, item := range items {
-					if g.items[item] != g.gen {
-						g.qBuf = append(g.qBuf, item)
-						g.items[item] = g.gen
+			dst = g.collect(dst, EncodeGridKey(cx, cy))
+		}
+	}
+
+	return dst
+}
+
+// QueryPrecise is like Query but only returns items whose inserted AABB
+// actually intersects the region.
+func (g *Grid[T]) QueryPrecise(region [4]float32) []T {
+	items := g.Query(region)
+
+	bounds := RegionAABB(region)
+
+	n := 0
+	for _, item := range items {
+		if g.itemBounds[item].Intersects(bounds) {
+			items[n] = item
+			n++
+		}
+	}
+
+	g.qBuf = items[:n]
+	return g.qBuf
+}
+
+// QueryPoint returns all items stored in the cell containing the point (x, y).
+//
+// The result is a broad-phase candidate set for that one cell.
+func (g *Grid[T]) QueryPoint(x, y float32) []T {
+	g.qBuf = g.qBuf[:0]
+	g.nextGen()
+
+	cx := int32(math.Floor(float64(x / g.cellWidth)))
+	cy := int32(math.Floor(float64(y / g.cellHeight)))
+	g.qBuf = g.collect(g.qBuf, EncodeGridKey(cx, cy))
+
+	return g.qBuf
+}
+
+// QueryCircle returns all items stored in cells overlapping the given circle.
+//
+// The result is a broad-phase candidate set and may include items whose bounds
+// do not intersect the circle itself.
+func (g *Grid[T]) QueryCircle(cx, cy, radius float32) []T {
+	g.qBuf = g.qBuf[:0]
+	if radius <= 0 {
+		return g.qBuf
+	}
+	g.nextGen()
+
+	r2 := radius * radius
+	minCellX, minCellY, maxCellX, maxCellY := g.cellRange(cx-radius, cy-radius, cx+radius, cy+radius)
+	for y := minCellY; y < maxCellY; y++ {
+		for x := minCellX; x < maxCellX; x++ {
+			cellMinX := float32(x) * g.cellWidth
+			cellMinY := float32(y) * g.cellHeight
+			dx := cx - max(cellMinX, min(cx, cellMinX+g.cellWidth))
+			dy := cy - max(cellMinY, min(cy, cellMinY+g.cellHeight))
+			if dx*dx+dy*dy <= r2 {
+				g.qBuf = g.collect(g.qBuf, EncodeGridKey(x, y))
+			}
+		}
+	}
+
+	return g.qBuf
+}
+
+// Nearest returns the item whose inserted AABB is closest to the point (x, y).
+// Returns false if the grid is empty.
+func (g *Grid[T]) Nearest(x, y float32) (T, bool) {
+	return g.NearestFunc(x, y, func(item T) float32 {
+		b := g.itemBounds[item]
+		dx := max(b.MinX-x, 0, x-b.MaxX)
+		dy := max(b.MinY-y, 0, y-b.MaxY)
+		return float32(math.Sqrt(float64(dx*dx + dy*dy)))
+	})
+}
+
+// NearestFunc returns the item with the smallest dist, expanding rings of cells
+// outward from the point (x, y) until no unvisited cell can hold a closer item.
+//
+// dist must never report less than the distance from (x, y) to the cells the item occupies.
+// Once the scanned cells outnumber the items, the search falls back to a linear scan.
+// Returns false if the grid is empty or x or y is not finite.
+func (g *Grid[T]) NearestFunc(x, y float32, dist func(item T) float32) (nearest T, ok bool) {
+	if !isFinite(x) || !isFinite(y) {
+		return
+	}
+
+	lx, ly, hx, hy, found := g.cellExtent()
+	if !found {
+		return
+	}
+	minCX, minCY, maxCX, maxCY := int64(lx), int64(ly), int64(hx), int64(hy)
+
+	fx := math.Floor(float64(x / g.cellWidth))
+	fy := math.Floor(float64(y / g.cellHeight))
+	if fx < math.MinInt32 || fx > math.MaxInt32 || fy < math.MinInt32 || fy > math.MaxInt32 {
+		return g.nearestLinear(dist)
+	}
+
+	px, py := int64(fx), int64(fy)
+	maxRing := max(px-minCX, maxCX-px, py-minCY, maxCY-py)
+	step := min(g.cellWidth, g.cellHeight)
+
+	// The ring walk costs one unit per row and per cell visited; past this
+	// budget a linear scan over the items is cheaper.
+	budget := len(g.items)
+
+	g.nextGen()
+	best := float32(math.Inf(1))
+	visit := func(cx, cy int64) {
+		budget--
+		for _, item := range g.cells[EncodeGridKey(int32(cx), int32(cy))] {
+			if g.items[item] == g.gen {
+				continue
+			}
+			g.items[item] = g.gen
+			if d := dist(item); d < best {
+				best, nearest, ok = d, item, true
+			}
+		}
+	}
+
+	for r := int64(0); r <= maxRing; r++ {
+		if ok && best <= float32(r-1)*step {
+			break
+		}
+
+		loX, hiX := max(px-r, minCX), min(px+r, maxCX)
+		loY, hiY := max(py-r, minCY), min(py+r, maxCY)
+		for cy := loY; cy <= hiY; cy++ {
+			if budget--; budget < 0 {
+				return g.nearestLinear(dist)
+			}
+			if cy == py-r || cy == py+r {
+				for cx := loX; cx <= hiX; cx++ {
+					visit(cx, cy)
+					if budget < 0 {
+						return g.nearestLinear(dist)
 					}
 				}
+				continue
+			}
+			if px-r >= minCX {
+				visit(px-r, cy)
+			}
+			if r > 0 && px+r <= maxCX {
+				visit(px+r, cy)
 			}
 		}
 	}
 
-	return g.qBuf
+	return
+}
+
+// nearestLinear returns the item with the smallest dist by scanning every item.
+func (g *Grid[T]) nearestLinear(dist func(item T) float32) (nearest T, ok bool) {
+	best := float32(math.Inf(1))
+	for item := range g.items {
+		if d := dist(item); !ok || d < best {
+			best, nearest, ok = d, item, true
+		}
+	}
+	return
+}
+
+func isFinite(v float32) bool {
+	return !math.IsNaN(float64(v)) && !math.IsInf(float64(v), 0)
+}
+
+// nextGen advances the query generation used to dedup items.
+//
+// Invariant: every item stamp in g.items is less than the generation handed out
+// by nextGen, so a fresh query never mistakes an item as already visited. Items
+// are inserted with stamp 0 and generations start at 1; on wrap-around all stamps
+// are reset to restore the invariant.
+func (g *Grid[T]) nextGen() {
+	g.gen++
+	if g.gen == 0 {
+		for item := range g.items {
+			g.items[item] = 0
+		}
+		g.gen = 1
+	}
+}
+
+// Bounds returns the world-space extent spanned by all occupied cells.
+// Returns ok == false if the grid is empty.
+func (g *Grid[T]) Bounds() (minX, minY, maxX, maxY float32, ok bool) {
+	minCX, minCY, maxCX, maxCY, ok := g.cellExtent()
+	if !ok {
+		return
+	}
+	minX = float32(minCX) * g.cellWidth
+	minY = float32(minCY) * g.cellHeight
+	maxX = float32(int64(maxCX)+1) * g.cellWidth
+	maxY = float32(int64(maxCY)+1) * g.cellHeight
+	return
+}
+
+// cellExtent returns the inclusive range of occupied cell indices.
+func (g *Grid[T]) cellExtent() (minCX, minCY, maxCX, maxCY int32, ok bool) {
+	if len(g.cells) == 0 {
+		return
+	}
+
+	minCX, minCY = math.MaxInt32, math.MaxInt32
+	maxCX, maxCY = math.MinInt32, math.MinInt32
+	for key := range g.cells {
+		cx, cy := DecodeGridKey(key)
+		minCX, maxCX = min(minCX, cx), max(maxCX, cx)
+		minCY, maxCY = min(minCY, cy), max(maxCY, cy)
+	}
+
+	return minCX, minCY, maxCX, maxCY, true
+}
+
+// collect appends the items in the given cell to dst, skipping items already
+// stamped with the current generation.
+func (g *Grid[T]) collect(dst []T, key uint64) []T {
+	for _, item := range g.cells[key] {
+		if g.items[item] != g.gen {
+			dst = append(dst, item)
+			g.items[item] = g.gen
+		}
+	}
+	return dst
 }
 
 func (g *Grid[T]) QueryCells(region [4]float32) []uint64 {
@@ -238,3 +753,26 @@ func (g *Grid[T]) QueryCells(region [4]float32) []uint64 {
 
 	return cellKeys
 }
+
+// QueryWithCells is like calling Query and QueryCells together, but walks the region once.
+//
+// The items slice aliases the internal query buffer, as with Query.
+func (g *Grid[T]) QueryWithCells(region [4]float32) ([]T, []uint64) {
+	g.qBuf = g.qBuf[:0]
+	g.nextGen()
+
+	var cellKeys []uint64
+
+	minCellX, minCellY, maxCellX, maxCellY := g.cellRange(region[0], region[1], region[2], region[3])
+	for cy := minCellY; cy < maxCellY; cy++ {
+		for cx := minCellX; cx < maxCellX; cx++ {
+			key := EncodeGridKey(cx, cy)
+			if _, exists := g.cells[key]; exists {
+				cellKeys = append(cellKeys, key)
+				g.qBuf = g.collect(g.qBuf, key)
+			}
+		}
+	}
+
+	return g.qBuf, cellKeys
+}
@@ -1,7 +1,9 @@
 package hash
 
 import (
+	"math"
 	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -151,3 +153,583 @@ func BenchmarkMinimalistGridSpanningInsert(b *testing.B) {
 		grid.Insert(item, [4]float32{x, y, x + 200, y + 200}, NoGridPadding)
 	}
 }
+
+func TestGridQueryGenerationWrap(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	items := generateItems(10)
+	for _, item := range items {
+		grid.Insert(item, [4]float32{0, 0, 100, 100}, NoGridPadding)
+	}
+
+	grid.gen = math.MaxUint64 - 1
+	if got := len(grid.Query([4]float32{0, 0, 100, 100})); got != len(items) {
+		t.Fatalf("query before wrap: got %d items, want %d", got, len(items))
+	}
+
+	// Next query wraps the generation counter.
+	if got := len(grid.Query([4]float32{0, 0, 100, 100})); got != len(items) {
+		t.Fatalf("query at wrap: got %d items, want %d", got, len(items))
+	}
+
+	extra := TestItem{ID: len(items)}
+	grid.Insert(extra, [4]float32{0, 0, 10, 10}, NoGridPadding)
+	if got := len(grid.Query([4]float32{0, 0, 100, 100})); got != len(items)+1 {
+		t.Fatalf("query after wrap: got %d items, want %d", got, len(items)+1)
+	}
+}
+
+func TestGridQueryAfterClear(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	items := generateItems(10)
+	for _, item := range items {
+		grid.Insert(item, [4]float32{0, 0, 100, 100}, NoGridPadding)
+	}
+	for range 3 {
+		grid.Query([4]float32{0, 0, 100, 100})
+	}
+
+	grid.Clear()
+	for _, item := range items {
+		grid.Insert(item, [4]float32{0, 0, 100, 100}, NoGridPadding)
+	}
+
+	if got := len(grid.Query([4]float32{0, 0, 100, 100})); got != len(items) {
+		t.Fatalf("got %d items, want %d", got, len(items))
+	}
+}
+
+func TestGridKeyRoundTrip(t *testing.T) {
+	cases := [][2]int32{
+		{0, 0},
+		{-1, -1},
+		{1, -1},
+		{math.MinInt32, math.MinInt32},
+		{math.MaxInt32, math.MaxInt32},
+		{math.MinInt32, math.MaxInt32},
+		{math.MaxInt32, math.MinInt32},
+	}
+	seen := make(map[uint64][2]int32)
+	for _, c := range cases {
+		key := EncodeGridKey(c[0], c[1])
+		if x, y := DecodeGridKey(key); x != c[0] || y != c[1] {
+			t.Errorf("DecodeGridKey(EncodeGridKey(%d, %d)) = (%d, %d)", c[0], c[1], x, y)
+		}
+		if prev, dup := seen[key]; dup {
+			t.Errorf("EncodeGridKey(%d, %d) collides with %v", c[0], c[1], prev)
+		}
+		seen[key] = c
+	}
+}
+
+func TestEncodeGridKeyChecked(t *testing.T) {
+	cases := []struct {
+		x, y int64
+		ok   bool
+	}{
+		{0, 0, true},
+		{math.MinInt32, math.MaxInt32, true},
+		{math.MinInt32 - 1, 0, false},
+		{0, math.MinInt32 - 1, false},
+		{math.MaxInt32 + 1, 0, false},
+		{0, math.MaxInt32 + 1, false},
+	}
+	for _, c := range cases {
+		key, ok := EncodeGridKeyChecked(c.x, c.y)
+		if ok != c.ok {
+			t.Errorf("EncodeGridKeyChecked(%d, %d) ok = %v, want %v", c.x, c.y, ok, c.ok)
+			continue
+		}
+		if ok && key != EncodeGridKey(int32(c.x), int32(c.y)) {
+			t.Errorf("EncodeGridKeyChecked(%d, %d) = %d, want %d", c.x, c.y, key, EncodeGridKey(int32(c.x), int32(c.y)))
+		}
+	}
+}
+
+func TestGridSnapshotRestore(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	items := generateItems(4)
+	for i, item := range items {
+		x := float32(i * 100)
+		grid.Insert(item, [4]float32{x, 0, x + 32, 32}, NoGridPadding)
+	}
+
+	snap := grid.Snapshot()
+
+	grid.Remove(items[0])
+	grid.Update(items[1], [4]float32{1000, 1000, 1032, 1032}, NoGridPadding)
+	grid.Insert(TestItem{ID: 99}, [4]float32{0, 0, 32, 32}, NoGridPadding)
+
+	for range 2 {
+		grid.Restore(snap)
+
+		if grid.Len() != len(items) {
+			t.Fatalf("Len() = %d, want %d", grid.Len(), len(items))
+		}
+		if got := grid.Query([4]float32{0, 0, 32, 32}); len(got) != 1 || got[0] != items[0] {
+			t.Fatalf("Query at items[0] = %v, want [%v]", got, items[0])
+		}
+		if got := grid.Query([4]float32{1000, 1000, 1032, 1032}); len(got) != 0 {
+			t.Fatalf("Query at moved position = %v, want none", got)
+		}
+
+		// Mutating the restored grid must not leak into the snapshot.
+		grid.Remove(items[2])
+	}
+}
+
+func TestGridCellOrderStable(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	items := generateItems(8)
+	for _, item := range items {
+		grid.Insert(item, [4]float32{0, 0, 32, 32}, NoGridPadding)
+	}
+
+	grid.Remove(items[1])
+	grid.Remove(items[4])
+	grid.RemoveFunc(func(item TestItem) bool { return item.ID == 6 })
+	grid.Insert(items[1], [4]float32{0, 0, 32, 32}, NoGridPadding)
+
+	var got []int
+	grid.ForEachInCell(EncodeGridKey(0, 0), func(item TestItem) {
+		got = append(got, item.ID)
+	})
+
+	want := []int{0, 2, 3, 5, 7, 1}
+	if !slices.Equal(got, want) {
+		t.Fatalf("cell order = %v, want %v", got, want)
+	}
+}
+
+func TestGridRemoveInRegionStraddling(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	inside := TestItem{ID: 0}
+	straddling := TestItem{ID: 1}
+	outside := TestItem{ID: 2}
+
+	grid.Insert(inside, [4]float32{10, 10, 20, 20}, NoGridPadding)
+	grid.Insert(straddling, [4]float32{10, 10, 300, 300}, NoGridPadding)
+	grid.Insert(outside, [4]float32{200, 200, 250, 250}, NoGridPadding)
+
+	if got := grid.RemoveInRegion([4]float32{0, 0, 64, 64}); got != 2 {
+		t.Fatalf("RemoveInRegion() = %d, want 2", got)
+	}
+	if grid.Contains(inside) || grid.Contains(straddling) || !grid.Contains(outside) {
+		t.Fatal("unexpected membership after RemoveInRegion")
+	}
+
+	// The straddling item must be gone from cells outside the region too.
+	for _, item := range grid.Query([4]float32{0, 0, 320, 320}) {
+		if item == straddling {
+			t.Fatal("straddling item still present outside the region")
+		}
+	}
+	if got := len(grid.Cells()); got != 1 {
+		t.Fatalf("len(Cells()) = %d, want 1", got)
+	}
+}
+
+func TestGridEdgeModes(t *testing.T) {
+	cases := []struct {
+		name   string
+		mode   GridEdgeMode
+		region [4]float32
+		want   []uint64
+	}{
+		{"exclusive fills one cell", GridEdgeExclusive, [4]float32{0, 0, 64, 64},
+			[]uint64{EncodeGridKey(0, 0)}},
+		{"exclusive point on boundary", GridEdgeExclusive, [4]float32{64, 64, 64, 64},
+			[]uint64{EncodeGridKey(1, 1)}},
+		{"exclusive negative cell", GridEdgeExclusive, [4]float32{-64, -64, 0, 0},
+			[]uint64{EncodeGridKey(-1, -1)}},
+		{"inclusive fills one cell", GridEdgeInclusive, [4]float32{0, 0, 64, 64},
+			[]uint64{EncodeGridKey(0, 0), EncodeGridKey(1, 0), EncodeGridKey(0, 1), EncodeGridKey(1, 1)}},
+		{"inclusive point on boundary", GridEdgeInclusive, [4]float32{64, 64, 64, 64},
+			[]uint64{EncodeGridKey(1, 1)}},
+		{"inclusive interior", GridEdgeInclusive, [4]float32{10, 10, 20, 20},
+			[]uint64{EncodeGridKey(0, 0)}},
+		{"exclusive inverted", GridEdgeExclusive, [4]float32{700, 700, 0, 0}, nil},
+		{"inclusive inverted", GridEdgeInclusive, [4]float32{700, 700, 0, 0}, nil},
+	}
+	for _, c := range cases {
+		grid := NewGrid[TestItem](64.0, 64.0)
+		grid.SetEdgeMode(c.mode)
+		grid.Insert(TestItem{ID: 1}, c.region, NoGridPadding)
+
+		got := grid.CellsSorted()
+		want := slices.Sorted(slices.Values(c.want))
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: cells = %v, want %v", c.name, got, want)
+		}
+		if items := grid.Query(c.region); len(items) != min(len(c.want), 1) {
+			t.Errorf("%s: Query over the inserted region returned %d items, want %d", c.name, len(items), min(len(c.want), 1))
+		}
+	}
+}
+
+// aabbDistance is the reference distance used by Nearest.
+func aabbDistance(b [4]float32, x, y float32) float32 {
+	dx := max(b[0]-x, 0, x-b[2])
+	dy := max(b[1]-y, 0, y-b[3])
+	return float32(math.Sqrt(float64(dx*dx + dy*dy)))
+}
+
+func TestGridNearestBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	cases := []struct {
+		name       string
+		cellSize   [2]float32
+		grids      int
+		minItems   int
+		maxItems   int
+		span, size float32
+		samples    int
+	}{
+		// Dense: the ring walk finishes well within its budget.
+		{"dense", [2]float32{32, 48}, 1, 300, 300, 2000, 80, 500},
+		// Sparse: a few items spread over many cells exhaust the budget mid-row.
+		{"sparse", [2]float32{1, 1}, 2000, 1, 4, 8, 1, 10},
+	}
+	for _, c := range cases {
+		for _, padding := range []GridItemPadding{NoGridPadding, GridCellPadding} {
+			for range c.grids {
+				grid := NewGrid[TestItem](c.cellSize[0], c.cellSize[1])
+				bounds := make(map[TestItem][4]float32)
+				for _, item := range generateItems(c.minItems + rng.Intn(c.maxItems-c.minItems+1)) {
+					x := rng.Float32()*c.span - c.span/2
+					y := rng.Float32()*c.span - c.span/2
+					b := [4]float32{x, y, x + rng.Float32()*c.size, y + rng.Float32()*c.size}
+					bounds[item] = b
+					grid.Insert(item, b, padding)
+				}
+
+				for range c.samples {
+					// Sample both inside and well outside the occupied extent.
+					x := rng.Float32()*c.span*1.5 - c.span*0.75
+					y := rng.Float32()*c.span*1.5 - c.span*0.75
+
+					want := float32(math.Inf(1))
+					for _, b := range bounds {
+						want = min(want, aabbDistance(b, x, y))
+					}
+
+					got, ok := grid.Nearest(x, y)
+					if !ok {
+						t.Fatalf("%s, padding=%d: Nearest(%v, %v) found nothing", c.name, padding, x, y)
+					}
+					if d := aabbDistance(bounds[got], x, y); d != want {
+						t.Fatalf("%s, padding=%d: Nearest(%v, %v) distance = %v, want %v", c.name, padding, x, y, d, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGridNearestCases(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	if _, ok := grid.Nearest(0, 0); ok {
+		t.Fatal("Nearest on an empty grid reported a result")
+	}
+
+	a, b := TestItem{ID: 1}, TestItem{ID: 2}
+	grid.Insert(a, [4]float32{0, 0, 10, 10}, NoGridPadding)
+	grid.Insert(b, [4]float32{100, 0, 110, 10}, NoGridPadding)
+
+	// Point far outside the occupied extent.
+	if got, ok := grid.Nearest(-5000, 5); !ok || got != a {
+		t.Fatalf("Nearest outside extent = %v, %v, want %v, true", got, ok, a)
+	}
+
+	// Point equidistant from both items.
+	got, ok := grid.Nearest(55, 5)
+	if !ok || (got != a && got != b) {
+		t.Fatalf("Nearest on a tie = %v, %v, want one of %v or %v", got, ok, a, b)
+	}
+
+	for _, p := range [][2]float32{
+		{float32(math.NaN()), 0},
+		{0, float32(math.Inf(1))},
+	} {
+		if _, ok := grid.Nearest(p[0], p[1]); ok {
+			t.Fatalf("Nearest(%v, %v) reported a result for a non-finite point", p[0], p[1])
+		}
+	}
+}
+
+func TestGridNearestSparse(t *testing.T) {
+	grid := NewGrid[TestItem](1.0, 1.0)
+	far := TestItem{ID: 1}
+	grid.Insert(TestItem{ID: 0}, [4]float32{0, 0, 1, 1}, NoGridPadding)
+	grid.Insert(far, [4]float32{20000, 20000, 20001, 20001}, NoGridPadding)
+
+	// Must fall back to a linear scan instead of walking the empty space.
+	if got, ok := grid.Nearest(15000, 15000); !ok || got != far {
+		t.Fatalf("Nearest = %v, %v, want %v, true", got, ok, far)
+	}
+	if got, ok := grid.Nearest(1e10, 1e10); !ok || got != far {
+		t.Fatalf("Nearest at a huge point = %v, %v, want %v, true", got, ok, far)
+	}
+}
+
+// assertSameCells fails if the two grids do not hold the same items in the same cells.
+func assertSameCells(t *testing.T, got, want *Grid[TestItem]) {
+	t.Helper()
+
+	gotKeys, wantKeys := got.CellsSorted(), want.CellsSorted()
+	if !slices.Equal(gotKeys, wantKeys) {
+		t.Fatalf("cells = %v, want %v", gotKeys, wantKeys)
+	}
+	for _, key := range wantKeys {
+		var gotItems, wantItems []TestItem
+		got.ForEachInCell(key, func(item TestItem) { gotItems = append(gotItems, item) })
+		want.ForEachInCell(key, func(item TestItem) { wantItems = append(wantItems, item) })
+
+		slices.SortFunc(gotItems, func(a, b TestItem) int { return a.ID - b.ID })
+		slices.SortFunc(wantItems, func(a, b TestItem) int { return a.ID - b.ID })
+		if !slices.Equal(gotItems, wantItems) {
+			t.Fatalf("cell %d items = %v, want %v", key, gotItems, wantItems)
+		}
+	}
+}
+
+func TestGridUpdateMatchesRebuild(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	grid := NewGrid[TestItem](64.0, 64.0)
+	items := generateItems(50)
+	regions := make([][4]float32, len(items))
+	paddings := make([]GridItemPadding, len(items))
+
+	randomRegion := func() [4]float32 {
+		x := rng.Float32()*1000 - 500
+		y := rng.Float32()*1000 - 500
+		return [4]float32{x, y, x + rng.Float32()*200, y + rng.Float32()*200}
+	}
+
+	for i, item := range items {
+		regions[i] = randomRegion()
+		grid.Insert(item, regions[i], NoGridPadding)
+	}
+
+	for step := range 20 {
+		for i, item := range items {
+			// Small moves keep most cells, large moves replace them all.
+			if rng.Intn(2) == 0 {
+				dx, dy := rng.Float32()*40-20, rng.Float32()*40-20
+				r := regions[i]
+				regions[i] = [4]float32{r[0] + dx, r[1] + dy, r[2] + dx, r[3] + dy}
+			} else {
+				regions[i] = randomRegion()
+			}
+			paddings[i] = GridItemPadding(rng.Intn(2))
+			if !grid.Update(item, regions[i], paddings[i]) {
+				t.Fatalf("step %d: Update(%v) = false for a present item", step, item)
+			}
+		}
+
+		fresh := NewGrid[TestItem](64.0, 64.0)
+		for i, item := range items {
+			fresh.Insert(item, regions[i], paddings[i])
+		}
+		assertSameCells(t, grid, fresh)
+	}
+
+	if grid.Update(TestItem{ID: -1}, [4]float32{0, 0, 1, 1}, NoGridPadding) {
+		t.Fatal("Update = true for an absent item")
+	}
+}
+
+func TestGridMoveTo(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	item := TestItem{ID: 1}
+
+	if grid.MoveTo(item, [4]float32{0, 0, 32, 32}, NoGridPadding) {
+		t.Fatal("MoveTo = true for an absent item")
+	}
+	if !grid.Contains(item) {
+		t.Fatal("MoveTo did not insert an absent item")
+	}
+
+	if !grid.MoveTo(item, [4]float32{100, 100, 140, 140}, NoGridPadding) {
+		t.Fatal("MoveTo = false for a present item")
+	}
+
+	fresh := NewGrid[TestItem](64.0, 64.0)
+	fresh.Insert(item, [4]float32{100, 100, 140, 140}, NoGridPadding)
+	assertSameCells(t, grid, fresh)
+}
+
+func TestGridQueryCircle(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	center := TestItem{ID: 0}
+	edge := TestItem{ID: 1}
+	corner := TestItem{ID: 2}
+	grid.Insert(center, [4]float32{30, 30, 34, 34}, NoGridPadding)
+	grid.Insert(edge, [4]float32{70, 10, 80, 20}, NoGridPadding)
+	grid.Insert(corner, [4]float32{70, 70, 80, 80}, NoGridPadding)
+
+	// The bounding box of the circle reaches cell (1, 1), but the circle itself does not.
+	got := grid.QueryCircle(32, 32, 40)
+	slices.SortFunc(got, func(a, b TestItem) int { return a.ID - b.ID })
+	if want := []TestItem{center, edge}; !slices.Equal(got, want) {
+		t.Fatalf("QueryCircle = %v, want %v", got, want)
+	}
+
+	for _, radius := range []float32{0, -1} {
+		if got := grid.QueryCircle(32, 32, radius); len(got) != 0 {
+			t.Fatalf("QueryCircle with radius %v = %v, want none", radius, got)
+		}
+	}
+}
+
+func TestGridQueryPrecise(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	near := TestItem{ID: 0}
+	far := TestItem{ID: 1}
+	grid.Insert(near, [4]float32{0, 0, 10, 10}, NoGridPadding)
+	grid.Insert(far, [4]float32{50, 50, 60, 60}, NoGridPadding)
+
+	region := [4]float32{0, 0, 20, 20}
+	if got := grid.Query(region); len(got) != 2 {
+		t.Fatalf("Query = %v, want both same-cell items", got)
+	}
+	if got := grid.QueryPrecise(region); !slices.Equal(got, []TestItem{near}) {
+		t.Fatalf("QueryPrecise = %v, want [%v]", got, near)
+	}
+
+	// Touching edges count as overlap.
+	if got := grid.QueryPrecise([4]float32{10, 10, 50, 50}); len(got) != 2 {
+		t.Fatalf("QueryPrecise on touching edges = %v, want both items", got)
+	}
+}
+
+func TestGridQueryPointBoundary(t *testing.T) {
+	a := TestItem{ID: 0}
+	b := TestItem{ID: 1}
+	cases := []struct {
+		name string
+		mode GridEdgeMode
+		x, y float32
+		want []TestItem
+	}{
+		{"exclusive inside", GridEdgeExclusive, 32, 32, []TestItem{a}},
+		{"exclusive on min edge", GridEdgeExclusive, 0, 0, []TestItem{a}},
+		{"exclusive on shared boundary", GridEdgeExclusive, 64, 64, []TestItem{b}},
+		{"inclusive on shared boundary", GridEdgeInclusive, 64, 64, []TestItem{a, b}},
+		{"unoccupied cell", GridEdgeExclusive, -10, -10, nil},
+	}
+	for _, c := range cases {
+		grid := NewGrid[TestItem](64.0, 64.0)
+		grid.SetEdgeMode(c.mode)
+		grid.Insert(a, [4]float32{0, 0, 64, 64}, NoGridPadding)
+		grid.Insert(b, [4]float32{64, 64, 100, 100}, NoGridPadding)
+
+		got := grid.QueryPoint(c.x, c.y)
+		slices.SortFunc(got, func(a, b TestItem) int { return a.ID - b.ID })
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%s: QueryPoint(%v, %v) = %v, want %v", c.name, c.x, c.y, got, c.want)
+		}
+	}
+}
+
+func TestGridRemoveInRegionKeepsQueryResults(t *testing.T) {
+	grid := NewGrid[TestItem](64.0, 64.0)
+	kept := TestItem{ID: 1}
+	grid.Insert(kept, [4]float32{0, 0, 10, 10}, NoGridPadding)
+	grid.Insert(TestItem{ID: 2}, [4]float32{500, 500, 510, 510}, NoGridPadding)
+
+	res := grid.Query([4]float32{0, 0, 10, 10})
+	grid.RemoveInRegion([4]float32{500, 500, 510, 510})
+
+	if !slices.Equal(res, []TestItem{kept}) {
+		t.Fatalf("earlier Query result = %v, want [%v]", res, kept)
+	}
+}
+
+func TestGridBounds(t *testing.T) {
+	grid := NewGrid[TestItem](10.0, 20.0)
+	if _, _, _, _, ok := grid.Bounds(); ok {
+		t.Fatal("Bounds on an empty grid reported ok")
+	}
+
+	// Cells (-1, 0) and (3, 2): the max edge is the far side of the last cell.
+	grid.Insert(TestItem{ID: 1}, [4]float32{-5, 5, 3, 15}, NoGridPadding)
+	grid.Insert(TestItem{ID: 2}, [4]float32{30, 41, 35, 45}, NoGridPadding)
+
+	minX, minY, maxX, maxY, ok := grid.Bounds()
+	if !ok || minX != -10 || minY != 0 || maxX != 40 || maxY != 60 {
+		t.Fatalf("Bounds() = (%v, %v, %v, %v, %v), want (-10, 0, 40, 60, true)", minX, minY, maxX, maxY, ok)
+	}
+
+	grid.Clear()
+	grid.Insert(TestItem{ID: 3}, [4]float32{-25, -45, -21, -41}, NoGridPadding)
+	minX, minY, maxX, maxY, ok = grid.Bounds()
+	if !ok || minX != -30 || minY != -60 || maxX != -20 || maxY != -40 {
+		t.Fatalf("Bounds() = (%v, %v, %v, %v, %v), want (-30, -60, -20, -40, true)", minX, minY, maxX, maxY, ok)
+	}
+}
+
+func TestGridQueryWithCells(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	grid := NewGrid[TestItem](64.0, 64.0)
+	for _, item := range generateItems(200) {
+		x := rng.Float32()*1000 - 500
+		y := rng.Float32()*1000 - 500
+		// Items up to 200 units wide span several cells.
+		grid.Insert(item, [4]float32{x, y, x + rng.Float32()*200, y + rng.Float32()*200}, NoGridPadding)
+	}
+
+	byID := func(a, b TestItem) int { return a.ID - b.ID }
+	for range 50 {
+		x := rng.Float32()*1000 - 500
+		y := rng.Float32()*1000 - 500
+		region := [4]float32{x, y, x + rng.Float32()*300, y + rng.Float32()*300}
+
+		want := slices.Clone(grid.Query(region))
+		wantCells := grid.QueryCells(region)
+
+		got, gotCells := grid.QueryWithCells(region)
+		got = slices.Clone(got)
+
+		slices.SortFunc(got, byID)
+		slices.SortFunc(want, byID)
+		if !slices.Equal(got, want) {
+			t.Fatalf("QueryWithCells(%v) items = %v, want %v", region, got, want)
+		}
+		if !slices.Equal(gotCells, wantCells) {
+			t.Fatalf("QueryWithCells(%v) cells = %v, want %v", region, gotCells, wantCells)
+		}
+	}
+}
+
+func TestGridCompact(t *testing.T) {
+	cases := []struct {
+		name    string
+		hint    int
+		keep    int
+		wantCap int
+	}{
+		{"no hint", 0, 5, 5},
+		{"hint floor", 16, 5, 16},
+		{"within hint", 16, 12, 16},
+	}
+	for _, c := range cases {
+		grid := NewGridWithHint[TestItem](64.0, 64.0, c.hint)
+		items := generateItems(100)
+		for _, item := range items {
+			grid.Insert(item, [4]float32{0, 0, 32, 32}, NoGridPadding)
+		}
+		for _, item := range items[c.keep:] {
+			grid.Remove(item)
+		}
+
+		key := EncodeGridKey(0, 0)
+		grid.Compact()
+		if got := cap(grid.cells[key]); got != c.wantCap {
+			t.Errorf("%s: cap after Compact = %d, want %d", c.name, got, c.wantCap)
+		}
+		if got := grid.CellCount(key); got != c.keep {
+			t.Errorf("%s: CellCount after Compact = %d, want %d", c.name, got, c.keep)
+		}
+	}
+}
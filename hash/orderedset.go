@@ -0,0 +1,75 @@
+package hash
+
+import "iter"
+
+// OrderedSet is a set that iterates in insertion order.
+//
+// Remove shifts later items down to preserve order, so it is O(n).
+type OrderedSet[T comparable] struct {
+	items []T
+	index map[T]int
+}
+
+func NewOrderedSet[T comparable](size ...int) *OrderedSet[T] {
+	sz := 0
+	if len(size) > 0 {
+		sz = size[0]
+	}
+	return &OrderedSet[T]{
+		items: make([]T, 0, sz),
+		index: make(map[T]int, sz),
+	}
+}
+
+func (s *OrderedSet[T]) Add(item T) bool {
+	if s.Contains(item) {
+		return false
+	}
+	s.index[item] = len(s.items)
+	s.items = append(s.items, item)
+	return true
+}
+
+func (s *OrderedSet[T]) Remove(item T) {
+	i, exists := s.index[item]
+	if !exists {
+		return
+	}
+	delete(s.index, item)
+
+	copy(s.items[i:], s.items[i+1:])
+	var zero T
+	s.items[len(s.items)-1] = zero
+	s.items = s.items[:len(s.items)-1]
+
+	for j := i; j < len(s.items); j++ {
+		s.index[s.items[j]] = j
+	}
+}
+
+func (s *OrderedSet[T]) Contains(item T) bool {
+	_, exists := s.index[item]
+	return exists
+}
+
+func (s *OrderedSet[T]) Size() int {
+	return len(s.items)
+}
+
+// ToSlice returns a copy of the items in insertion order.
+func (s *OrderedSet[T]) ToSlice() []T {
+	slice := make([]T, len(s.items))
+	copy(slice, s.items)
+	return slice
+}
+
+// All returns an iterator over the items in insertion order.
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package hash
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedSetOrder(t *testing.T) {
+	s := NewOrderedSet[int]()
+	for _, v := range []int{5, 3, 9, 3, 1} {
+		s.Add(v)
+	}
+	if got, want := s.ToSlice(), []int{5, 3, 9, 1}; !slices.Equal(got, want) {
+		t.Fatalf("ToSlice() = %v, want %v", got, want)
+	}
+
+	s.Remove(3)
+	s.Remove(42)
+	if got, want := s.ToSlice(), []int{5, 9, 1}; !slices.Equal(got, want) {
+		t.Fatalf("after Remove, ToSlice() = %v, want %v", got, want)
+	}
+
+	// Index bookkeeping must stay correct after shifting.
+	s.Remove(1)
+	s.Add(3)
+	if got, want := slices.Collect(s.All()), []int{5, 9, 3}; !slices.Equal(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+	if s.Size() != 3 || !s.Contains(9) || s.Contains(1) {
+		t.Fatalf("unexpected membership: %v", s.ToSlice())
+	}
+}
@@ -39,3 +39,60 @@ func (s *Set[T]) ToSlice() []T {
 	}
 	return slice
 }
+
+// Filter returns a new set containing the items for which pred returns true.
+func (s *Set[T]) Filter(pred func(T) bool) Set[T] {
+	result := NewSet[T]()
+	for item := range *s {
+		if pred(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+// Map returns a new set containing fn applied to each item of s.
+func Map[T, U comparable](s Set[T], fn func(T) U) Set[U] {
+	result := NewSet[U](len(s))
+	for item := range s {
+		result.Add(fn(item))
+	}
+	return result
+}
+
+// Union returns a new set containing the items in either a or b.
+func Union[T comparable](a, b Set[T]) Set[T] {
+	result := NewSet[T](len(a) + len(b))
+	for item := range a {
+		result.Add(item)
+	}
+	for item := range b {
+		result.Add(item)
+	}
+	return result
+}
+
+// Intersection returns a new set containing the items in both a and b.
+func Intersection[T comparable](a, b Set[T]) Set[T] {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	result := NewSet[T]()
+	for item := range a {
+		if b.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+// Difference returns a new set containing the items in a that are not in b.
+func Difference[T comparable](a, b Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range a {
+		if !b.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package hash
+
+import (
+	"slices"
+	"testing"
+)
+
+func setOf(items ...int) Set[int] {
+	s := NewSet[int]()
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+func sortedItems(s Set[int]) []int {
+	items := s.ToSlice()
+	slices.Sort(items)
+	return items
+}
+
+func TestSetOperations(t *testing.T) {
+	cases := []struct {
+		name                  string
+		a, b                  Set[int]
+		union, inter, aMinusB []int
+	}{
+		{"overlapping", setOf(1, 2, 3), setOf(2, 3, 4), []int{1, 2, 3, 4}, []int{2, 3}, []int{1}},
+		{"smaller left", setOf(2), setOf(1, 2, 3, 4), []int{1, 2, 3, 4}, []int{2}, nil},
+		{"smaller right", setOf(1, 2, 3, 4), setOf(4), []int{1, 2, 3, 4}, []int{4}, []int{1, 2, 3}},
+		{"disjoint", setOf(1), setOf(2), []int{1, 2}, nil, []int{1}},
+		{"nil right", setOf(1, 2), nil, []int{1, 2}, nil, []int{1, 2}},
+		{"nil left", nil, setOf(1, 2), []int{1, 2}, nil, nil},
+		{"both nil", nil, nil, nil, nil, nil},
+	}
+	for _, c := range cases {
+		aBefore, bBefore := sortedItems(c.a), sortedItems(c.b)
+
+		if got := sortedItems(Union(c.a, c.b)); !slices.Equal(got, c.union) {
+			t.Errorf("%s: Union = %v, want %v", c.name, got, c.union)
+		}
+		if got := sortedItems(Intersection(c.a, c.b)); !slices.Equal(got, c.inter) {
+			t.Errorf("%s: Intersection = %v, want %v", c.name, got, c.inter)
+		}
+		if got := sortedItems(Difference(c.a, c.b)); !slices.Equal(got, c.aMinusB) {
+			t.Errorf("%s: Difference = %v, want %v", c.name, got, c.aMinusB)
+		}
+
+		if !slices.Equal(sortedItems(c.a), aBefore) || !slices.Equal(sortedItems(c.b), bBefore) {
+			t.Errorf("%s: operands were modified", c.name)
+		}
+	}
+}
+
+func TestSetFilterAndMap(t *testing.T) {
+	s := setOf(1, 2, 3, 4)
+
+	even := s.Filter(func(v int) bool { return v%2 == 0 })
+	if got, want := sortedItems(even), []int{2, 4}; !slices.Equal(got, want) {
+		t.Fatalf("Filter = %v, want %v", got, want)
+	}
+
+	// Map dedups values that collide.
+	halves := Map(s, func(v int) int { return v / 2 })
+	if got, want := sortedItems(halves), []int{0, 1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("Map = %v, want %v", got, want)
+	}
+
+	even.Add(6)
+	if got, want := sortedItems(s), []int{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Fatalf("source set changed to %v, want %v", got, want)
+	}
+
+	var empty Set[int]
+	if got := empty.Filter(func(int) bool { return true }); got.Size() != 0 {
+		t.Fatalf("Filter on nil set = %v, want empty", got)
+	}
+	if got := Map(empty, func(v int) int { return v }); got.Size() != 0 {
+		t.Fatalf("Map on nil set = %v, want empty", got)
+	}
+}
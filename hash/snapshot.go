@@ -0,0 +1,51 @@
+package hash
+
+import "maps"
+
+// GridSnapshot is an independent copy of a Grid's state, created by Grid.Snapshot.
+type GridSnapshot[T comparable] struct {
+	cellWidth  float32
+	cellHeight float32
+	edgeMode   GridEdgeMode
+	cells      map[uint64][]T
+	itemCells  map[T][]uint64
+	itemBounds map[T]AABB
+}
+
+// Snapshot returns a deep copy of the grid's state that is unaffected by later mutations.
+func (g *Grid[T]) Snapshot() GridSnapshot[T] {
+	return GridSnapshot[T]{
+		cellWidth:  g.cellWidth,
+		cellHeight: g.cellHeight,
+		edgeMode:   g.edgeMode,
+		cells:      cloneSlices(g.cells),
+		itemCells:  cloneSlices(g.itemCells),
+		itemBounds: maps.Clone(g.itemBounds),
+	}
+}
+
+// Restore replaces the grid's state with a copy of the snapshot.
+// The snapshot can be restored any number of times.
+func (g *Grid[T]) Restore(s GridSnapshot[T]) {
+	g.Clear()
+
+	g.cellWidth = s.cellWidth
+	g.cellHeight = s.cellHeight
+	g.edgeMode = s.edgeMode
+	for key, items := range s.cells {
+		g.cells[key] = append([]T(nil), items...)
+	}
+	for item, keys := range s.itemCells {
+		g.items[item] = 0
+		g.itemCells[item] = append([]uint64(nil), keys...)
+	}
+	maps.Copy(g.itemBounds, s.itemBounds)
+}
+
+func cloneSlices[K comparable, V any](m map[K][]V) map[K][]V {
+	out := make(map[K][]V, len(m))
+	for key, values := range m {
+		out[key] = append([]V(nil), values...)
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package hash
+
+// SpatialHash is the common method set of a spatial index, allowing callers to
+// swap grid implementations behind a single type.
+type SpatialHash[T comparable] interface {
+	Insert(item T, region [4]float32, padding GridItemPadding) bool
+	Remove(item T)
+	Query(region [4]float32) []T
+	Clear()
+	Contains(item T) bool
+	Len() int
+}
+
+var _ SpatialHash[int] = (*Grid[int])(nil)
@@ -1,5 +1,11 @@
 package linq
 
+import (
+	"cmp"
+	"sync"
+	"sync/atomic"
+)
+
 // ========== Batch ==========
 
 func Batch[T any](items []T, size int) [][]T {
@@ -16,6 +22,21 @@ func Batch[T any](items []T, size int) [][]T {
 	return batches
 }
 
+// ========== BatchStrict ==========
+
+func BatchStrict[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		return nil
+	}
+
+	var batches [][]T
+	for i := 0; i+size <= len(items); i += size {
+		batches = append(batches, items[i:i+size])
+	}
+
+	return batches
+}
+
 // ========== Distinct ==========
 
 func Distinct[T comparable](items []T) []T {
@@ -31,3 +52,323 @@ func Distinct[T comparable](items []T) []T {
 
 	return result
 }
+
+// ========== Keys ==========
+
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ========== Values ==========
+
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
+
+// ========== Window ==========
+
+func Window[T any](items []T, size int) [][]T {
+	if size <= 0 || size > len(items) {
+		return nil
+	}
+
+	windows := make([][]T, 0, len(items)-size+1)
+	for i := 0; i+size <= len(items); i++ {
+		windows = append(windows, items[i:i+size])
+	}
+
+	return windows
+}
+
+// ========== Pairwise ==========
+
+func Pairwise[T any](items []T) [][2]T {
+	if len(items) < 2 {
+		return nil
+	}
+
+	pairs := make([][2]T, 0, len(items)-1)
+	for i := 1; i < len(items); i++ {
+		pairs = append(pairs, [2]T{items[i-1], items[i]})
+	}
+
+	return pairs
+}
+
+// ========== Scan ==========
+
+// Scan returns the accumulator value after each item. The seed itself is not included,
+// so the result has the same length as items.
+func Scan[T, A any](items []T, seed A, fn func(acc A, item T) A) []A {
+	result := make([]A, 0, len(items))
+
+	acc := seed
+	for _, item := range items {
+		acc = fn(acc, item)
+		result = append(result, acc)
+	}
+
+	return result
+}
+
+// ========== Tap ==========
+
+func Tap[T any](items []T, fn func(T)) []T {
+	for _, item := range items {
+		fn(item)
+	}
+	return items
+}
+
+// ========== ParallelMap ==========
+
+func ParallelMap[T, U any](items []T, workers int, fn func(T) U) []U {
+	result := make([]U, len(items))
+
+	parallelBatches(items, workers, func(offset int, batch []T) {
+		for i, item := range batch {
+			result[offset+i] = fn(item)
+		}
+	})
+
+	return result
+}
+
+// parallelBatches splits items into one batch per worker and calls fn for each
+// batch on its own goroutine, waiting for all of them to finish. It runs serially
+// when workers <= 1 or there are fewer than two items. A panic in any worker is
+// re-raised on the calling goroutine.
+func parallelBatches[T any](items []T, workers int, fn func(offset int, batch []T)) {
+	if workers <= 1 || len(items) < 2 {
+		fn(0, items)
+		return
+	}
+
+	size := (len(items) + workers - 1) / workers
+
+	var (
+		wg        sync.WaitGroup
+		panicOnce sync.Once
+		panicVal  any
+	)
+	for b, batch := range Batch(items, size) {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { panicVal = r })
+				}
+			}()
+			fn(b*size, batch)
+		})
+	}
+	wg.Wait()
+
+	if panicVal != nil {
+		panic(panicVal)
+	}
+}
+
+// ========== MaxBy ==========
+
+func MaxBy[T any, K cmp.Ordered](items []T, keyFn func(T) K) (T, bool) {
+	var best T
+	if len(items) == 0 {
+		return best, false
+	}
+
+	best = items[0]
+	bestKey := keyFn(best)
+	for _, item := range items[1:] {
+		if key := keyFn(item); key > bestKey {
+			best, bestKey = item, key
+		}
+	}
+
+	return best, true
+}
+
+// ========== MinBy ==========
+
+func MinBy[T any, K cmp.Ordered](items []T, keyFn func(T) K) (T, bool) {
+	var best T
+	if len(items) == 0 {
+		return best, false
+	}
+
+	best = items[0]
+	bestKey := keyFn(best)
+	for _, item := range items[1:] {
+		if key := keyFn(item); key < bestKey {
+			best, bestKey = item, key
+		}
+	}
+
+	return best, true
+}
+
+// ========== Clamp ==========
+
+// Clamp limits v to the range [lo, hi]. If lo > hi, lo is returned.
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	return max(lo, min(v, hi))
+}
+
+// ========== ClampSlice ==========
+
+// ClampSlice clamps every element of items in place using Clamp.
+func ClampSlice[T cmp.Ordered](items []T, lo, hi T) {
+	for i, item := range items {
+		items[i] = Clamp(item, lo, hi)
+	}
+}
+
+// ========== Repeat ==========
+
+func Repeat[T any](value T, count int) []T {
+	result := make([]T, max(count, 0))
+	for i := range result {
+		result[i] = value
+	}
+	return result
+}
+
+// ========== Range ==========
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+func Range[T Number](start T, count int, step T) []T {
+	result := make([]T, max(count, 0))
+	for i := range result {
+		result[i] = start + T(i)*step
+	}
+	return result
+}
+
+// ========== GroupConsecutive ==========
+
+func GroupConsecutive[T any, K comparable](items []T, keyFn func(T) K) [][]T {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var groups [][]T
+	start := 0
+	prev := keyFn(items[0])
+	for i := 1; i < len(items); i++ {
+		if key := keyFn(items[i]); key != prev {
+			groups = append(groups, items[start:i])
+			start, prev = i, key
+		}
+	}
+	groups = append(groups, items[start:])
+
+	return groups
+}
+
+// ========== SequenceEqual ==========
+
+func SequenceEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ========== ContainsAll ==========
+
+func ContainsAll[T comparable](items, targets []T) bool {
+	if len(targets) == 0 {
+		return true
+	}
+
+	present := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		present[item] = struct{}{}
+	}
+
+	for _, target := range targets {
+		if _, exists := present[target]; !exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ========== ParallelForEach ==========
+
+func ParallelForEach[T any](items []T, workers int, fn func(T)) {
+	parallelBatches(items, workers, func(_ int, batch []T) {
+		for _, item := range batch {
+			fn(item)
+		}
+	})
+}
+
+// ========== ParallelForEachErr ==========
+
+// ParallelForEachErr is like ParallelForEach but returns the first error reported by fn.
+// Workers stop picking up new items once any error has been reported.
+func ParallelForEachErr[T any](items []T, workers int, fn func(T) error) error {
+	var (
+		errOnce  sync.Once
+		firstErr error
+		failed   atomic.Bool
+	)
+
+	parallelBatches(items, workers, func(_ int, batch []T) {
+		for _, item := range batch {
+			if failed.Load() {
+				return
+			}
+			if err := fn(item); err != nil {
+				errOnce.Do(func() { firstErr = err })
+				failed.Store(true)
+				return
+			}
+		}
+	})
+
+	return firstErr
+}
+
+// ========== Coalesce ==========
+
+func Coalesce[T comparable](values ...T) T {
+	var zero T
+	for _, value := range values {
+		if value != zero {
+			return value
+		}
+	}
+	return zero
+}
+
+// ========== CoalesceFunc ==========
+
+func CoalesceFunc[T any](isEmpty func(T) bool, values ...T) T {
+	for _, value := range values {
+		if !isEmpty(value) {
+			return value
+		}
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,313 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []int
+		size  int
+		want  [][]int
+	}{
+		{"exact multiple", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"remainder", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"size larger than input", []int{1, 2}, 5, [][]int{{1, 2}}},
+		{"empty", nil, 2, nil},
+		{"non-positive size", []int{1, 2}, 0, nil},
+	}
+	for _, c := range cases {
+		if got := Batch(c.items, c.size); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: Batch(%v, %d) = %v, want %v", c.name, c.items, c.size, got, c.want)
+		}
+	}
+}
+
+func TestBatchStrict(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []int
+		size  int
+		want  [][]int
+	}{
+		{"exact multiple", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"remainder", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"size larger than input", []int{1, 2}, 5, nil},
+		{"empty", nil, 2, nil},
+		{"non-positive size", []int{1, 2}, 0, nil},
+	}
+	for _, c := range cases {
+		if got := BatchStrict(c.items, c.size); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: BatchStrict(%v, %d) = %v, want %v", c.name, c.items, c.size, got, c.want)
+		}
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+
+	for _, workers := range []int{0, 1, 3, 8, 2000} {
+		got := ParallelMap(items, workers, func(v int) int { return v * 2 })
+		if len(got) != len(items) {
+			t.Fatalf("workers=%d: got %d results, want %d", workers, len(got), len(items))
+		}
+		for i, v := range got {
+			if v != i*2 {
+				t.Fatalf("workers=%d: result[%d] = %d, want %d", workers, i, v, i*2)
+			}
+		}
+	}
+}
+
+func TestGroupConsecutive(t *testing.T) {
+	items := []int{1, 1, 2, 2, 2, 1, 3}
+	want := [][]int{{1, 1}, {2, 2, 2}, {1}, {3}}
+	if got := GroupConsecutive(items, func(v int) int { return v }); !reflect.DeepEqual(got, want) {
+		t.Fatalf("GroupConsecutive(%v) = %v, want %v", items, got, want)
+	}
+	if got := GroupConsecutive(nil, func(v int) int { return v }); got != nil {
+		t.Fatalf("GroupConsecutive(nil) = %v, want nil", got)
+	}
+}
+
+func TestParallelForEachErr(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	wantErr := errors.New("boom")
+	err := ParallelForEachErr(items, 4, func(v int) error {
+		if v == 42 {
+			return wantErr
+		}
+		return nil
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("ParallelForEachErr() = %v, want %v", err, wantErr)
+	}
+
+	var sum atomic.Int64
+	if err := ParallelForEachErr(items, 4, func(v int) error {
+		sum.Add(int64(v))
+		return nil
+	}); err != nil {
+		t.Fatalf("ParallelForEachErr() = %v, want nil", err)
+	}
+	if got := sum.Load(); got != 4950 {
+		t.Fatalf("sum = %d, want 4950", got)
+	}
+}
+
+func TestParallelForEachPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "worker panic" {
+			t.Fatalf("recovered %v, want worker panic", r)
+		}
+	}()
+
+	ParallelForEach([]int{1, 2, 3, 4}, 2, func(v int) {
+		if v == 3 {
+			panic("worker panic")
+		}
+	})
+	t.Fatal("ParallelForEach did not re-raise the worker panic")
+}
+
+func TestRange(t *testing.T) {
+	if got, want := Range(5, 4, -2), []int{5, 3, 1, -1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range(5, 4, -2) = %v, want %v", got, want)
+	}
+
+	// Each element is computed from its index, so float error does not accumulate.
+	got := Range[float32](0, 11, 0.1)
+	for i, v := range got {
+		if want := float32(i) * 0.1; v != want {
+			t.Errorf("Range[float32](0, 11, 0.1)[%d] = %v, want %v", i, v, want)
+		}
+	}
+	if got[10] != 1 {
+		t.Errorf("Range[float32](0, 11, 0.1)[10] = %v, want 1", got[10])
+	}
+
+	for _, count := range []int{0, -3} {
+		if got := Range(1, count, 1); got == nil || len(got) != 0 {
+			t.Errorf("Range(1, %d, 1) = %#v, want empty non-nil slice", count, got)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []int
+		size  int
+		want  [][]int
+	}{
+		{"overlapping", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"exact length", []int{1, 2, 3}, 3, [][]int{{1, 2, 3}}},
+		{"size larger than input", []int{1, 2}, 3, nil},
+		{"zero size", []int{1, 2}, 0, nil},
+		{"negative size", []int{1, 2}, -1, nil},
+		{"empty", nil, 1, nil},
+	}
+	for _, c := range cases {
+		if got := Window(c.items, c.size); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: Window(%v, %d) = %v, want %v", c.name, c.items, c.size, got, c.want)
+		}
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []int
+		want  [][2]int
+	}{
+		{"several", []int{1, 2, 3}, [][2]int{{1, 2}, {2, 3}}},
+		{"two", []int{1, 2}, [][2]int{{1, 2}}},
+		{"one", []int{1}, nil},
+		{"empty", nil, nil},
+	}
+	for _, c := range cases {
+		if got := Pairwise(c.items); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: Pairwise(%v) = %v, want %v", c.name, c.items, got, c.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+
+	if got, want := Scan([]int{1, 2, 3}, 10, sum), []int{11, 13, 16}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan = %v, want %v (same length as input, seed excluded)", got, want)
+	}
+	if got := Scan(nil, 10, sum); got == nil || len(got) != 0 {
+		t.Errorf("Scan(nil) = %#v, want empty non-nil slice", got)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	cases := []struct {
+		name      string
+		v, lo, hi int
+		want      int
+	}{
+		{"inside", 5, 0, 10, 5},
+		{"below", -3, 0, 10, 0},
+		{"above", 12, 0, 10, 10},
+		{"on bound", 10, 0, 10, 10},
+		{"inverted bounds return lo", 5, 10, 0, 10},
+		{"inverted bounds below", -5, 10, 0, 10},
+	}
+	for _, c := range cases {
+		if got := Clamp(c.v, c.lo, c.hi); got != c.want {
+			t.Errorf("%s: Clamp(%d, %d, %d) = %d, want %d", c.name, c.v, c.lo, c.hi, got, c.want)
+		}
+	}
+}
+
+func TestClampSlice(t *testing.T) {
+	items := []float64{-1, 0.5, 2}
+	alias := items
+
+	ClampSlice(items, 0, 1)
+	if want := []float64{0, 0.5, 1}; !reflect.DeepEqual(alias, want) {
+		t.Errorf("ClampSlice left %v, want %v in place", alias, want)
+	}
+}
+
+func TestSequenceEqual(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []int
+		want bool
+	}{
+		{"equal", []int{1, 2, 3}, []int{1, 2, 3}, true},
+		{"different order", []int{1, 2, 3}, []int{3, 2, 1}, false},
+		{"different length", []int{1, 2}, []int{1, 2, 3}, false},
+		{"nil and empty", nil, []int{}, true},
+		{"both nil", nil, nil, true},
+		{"nil and non-empty", nil, []int{1}, false},
+	}
+	for _, c := range cases {
+		if got := SequenceEqual(c.a, c.b); got != c.want {
+			t.Errorf("%s: SequenceEqual(%v, %v) = %v, want %v", c.name, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestContainsAll(t *testing.T) {
+	cases := []struct {
+		name           string
+		items, targets []int
+		want           bool
+	}{
+		{"all present", []int{1, 2, 3}, []int{3, 1}, true},
+		{"order independent", []int{3, 2, 1}, []int{1, 2, 3}, true},
+		{"duplicate targets", []int{1, 2}, []int{2, 2, 1}, true},
+		{"missing target", []int{1, 2}, []int{1, 4}, false},
+		{"nil targets", []int{1}, nil, true},
+		{"empty targets", nil, []int{}, true},
+		{"nil items", nil, []int{1}, false},
+	}
+	for _, c := range cases {
+		if got := ContainsAll(c.items, c.targets); got != c.want {
+			t.Errorf("%s: ContainsAll(%v, %v) = %v, want %v", c.name, c.items, c.targets, got, c.want)
+		}
+	}
+}
+
+func TestMaxByMinBy(t *testing.T) {
+	type entry struct {
+		name  string
+		score int
+	}
+	items := []entry{{"a", 1}, {"b", 3}, {"c", 3}, {"d", 1}}
+	score := func(e entry) int { return e.score }
+
+	// Ties keep the first element encountered.
+	if got, ok := MaxBy(items, score); !ok || got.name != "b" {
+		t.Errorf("MaxBy = %v, %v, want b, true", got, ok)
+	}
+	if got, ok := MinBy(items, score); !ok || got.name != "a" {
+		t.Errorf("MinBy = %v, %v, want a, true", got, ok)
+	}
+
+	if got, ok := MaxBy(nil, score); ok || got != (entry{}) {
+		t.Errorf("MaxBy(nil) = %v, %v, want zero, false", got, ok)
+	}
+	if got, ok := MinBy(nil, score); ok || got != (entry{}) {
+		t.Errorf("MinBy(nil) = %v, %v, want zero, false", got, ok)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	if got := Coalesce("", "a", "b"); got != "a" {
+		t.Errorf(`Coalesce("", "a", "b") = %q, want "a"`, got)
+	}
+	if got := Coalesce(0, 0, 0); got != 0 {
+		t.Errorf("Coalesce(0, 0, 0) = %d, want 0", got)
+	}
+	if got := Coalesce[int](); got != 0 {
+		t.Errorf("Coalesce() = %d, want 0", got)
+	}
+}
+
+func TestCoalesceFunc(t *testing.T) {
+	isEmpty := func(s []int) bool { return len(s) == 0 }
+
+	if got := CoalesceFunc(isEmpty, nil, []int{}, []int{1}, []int{2}); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("CoalesceFunc = %v, want [1]", got)
+	}
+	if got := CoalesceFunc(isEmpty, nil, []int{}); got != nil {
+		t.Errorf("CoalesceFunc with all empty = %v, want nil", got)
+	}
+}
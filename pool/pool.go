@@ -1,18 +1,48 @@
 package pool
 
+import "slices"
+
 // Pool is a generic object pool for reusing objects of Poolable types.
 //
-// Use sync.Pool from the standard library for concurrent use cases.
+// It is not safe for concurrent use; wrap it in a SyncPool for concurrent use cases.
 type Pool[T any] struct {
 	items []T
 
+	gets   uint64
+	misses uint64
+	puts   uint64
+
 	New func() T
+
+	// Reset, if set, is called on each object before it is stored by Put.
+	Reset func(*T)
+
+	// MaxSize caps the number of retained objects. Objects Put beyond the cap
+	// are dropped for the garbage collector. Zero means unlimited.
+	MaxSize int
+}
+
+// NewPool returns a Pool that creates objects with newFn. It panics if newFn is nil.
+func NewPool[T any](newFn func() T) *Pool[T] {
+	if newFn == nil {
+		panic("pool: NewPool called with nil New function")
+	}
+	return &Pool[T]{New: newFn}
+}
+
+func (p *Pool[T]) newItem() T {
+	if p.New == nil {
+		panic("pool: Get on empty pool with nil New function")
+	}
+	return p.New()
 }
 
 func (p *Pool[T]) Get() T {
+	p.gets++
 	n := len(p.items)
 	if n == 0 {
-		return p.New()
+		p.misses++
+		return p.newItem()
 	}
 	item := p.items[n-1]
 	p.items = p.items[:n-1]
@@ -20,9 +50,115 @@ func (p *Pool[T]) Get() T {
 }
 
 func (p *Pool[T]) Put(item T) {
+	p.puts++
+	if p.MaxSize > 0 && len(p.items) >= p.MaxSize {
+		return
+	}
+	if p.Reset != nil {
+		p.Reset(&item)
+	}
 	p.items = append(p.items, item)
 }
 
+// GetN returns a new slice holding n objects, taken from the pool first and
+// created with New for the remainder.
+func (p *Pool[T]) GetN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	return p.AppendN(make([]T, 0, n), n)
+}
+
+// AppendN is like GetN but appends the objects to dst and returns the extended slice.
+func (p *Pool[T]) AppendN(dst []T, n int) []T {
+	if n <= 0 {
+		return dst
+	}
+	dst = slices.Grow(dst, n)
+
+	take := min(n, len(p.items))
+	start := len(p.items) - take
+	dst = append(dst, p.items[start:]...)
+	clear(p.items[start:])
+	p.items = p.items[:start]
+
+	for range n - take {
+		dst = append(dst, p.newItem())
+	}
+
+	p.gets += uint64(n)
+	p.misses += uint64(n - take)
+	return dst
+}
+
+// PutN returns every object in items to the pool.
+func (p *Pool[T]) PutN(items []T) {
+	for _, item := range items {
+		p.Put(item)
+	}
+}
+
 func (p *Pool[T]) Len() int {
 	return len(p.items)
 }
+
+// Stats returns the number of Get calls, the number of those that had to call New,
+// and the number of Put calls made on the pool.
+func (p *Pool[T]) Stats() (gets, misses, puts uint64) {
+	return p.gets, p.misses, p.puts
+}
+
+// Prewarm stores n newly created objects so that subsequent Gets do not allocate.
+// It is a no-op if New is nil. Objects beyond MaxSize are not created.
+func (p *Pool[T]) Prewarm(n int) {
+	if p.New == nil {
+		return
+	}
+	if p.MaxSize > 0 {
+		n = min(n, p.MaxSize-len(p.items))
+	}
+	if n <= 0 {
+		return
+	}
+	p.items = slices.Grow(p.items, n)
+	for range n {
+		p.items = append(p.items, p.New())
+	}
+}
+
+// Clear removes all pooled objects so they can be garbage collected.
+// The backing slice capacity is kept for reuse; use Release to drop it as well.
+func (p *Pool[T]) Clear() {
+	clear(p.items)
+	p.items = p.items[:0]
+}
+
+// Drain removes every pooled object, calling fn on each in Get order.
+func (p *Pool[T]) Drain(fn func(T)) {
+	items := p.items
+	p.items = nil
+	for i := len(items) - 1; i >= 0; i-- {
+		fn(items[i])
+	}
+	clear(items)
+}
+
+// Shrink reallocates the backing slice so its capacity matches the number of
+// pooled objects, letting an oversized array be garbage collected.
+func (p *Pool[T]) Shrink() {
+	if cap(p.items) == len(p.items) {
+		return
+	}
+	if len(p.items) == 0 {
+		p.items = nil
+		return
+	}
+	items := make([]T, len(p.items))
+	copy(items, p.items)
+	p.items = items
+}
+
+// Release removes all pooled objects and drops the backing slice.
+func (p *Pool[T]) Release() {
+	p.items = nil
+}
@@ -0,0 +1,205 @@
+package pool
+
+import (
+	"slices"
+	"testing"
+)
+
+// counterPool returns a pool whose New hands out 100, 101, 102, ...
+func counterPool() *Pool[int] {
+	next := 100
+	return NewPool(func() int {
+		next++
+		return next - 1
+	})
+}
+
+func TestPoolAppendN(t *testing.T) {
+	cases := []struct {
+		name       string
+		pooled     []int
+		n          int
+		want       []int
+		wantLen    int
+		wantMisses uint64
+	}{
+		{"all from pool", []int{1, 2, 3}, 2, []int{2, 3}, 1, 0},
+		{"pool then New", []int{1, 2, 3}, 5, []int{1, 2, 3, 100, 101}, 0, 2},
+		{"empty pool", nil, 2, []int{100, 101}, 0, 2},
+		{"zero", []int{1}, 0, []int{7}, 1, 0},
+	}
+	for _, c := range cases {
+		p := counterPool()
+		p.PutN(c.pooled)
+
+		got := p.AppendN([]int{7}, c.n)
+		if c.n > 0 {
+			got = got[1:]
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%s: AppendN = %v, want %v", c.name, got, c.want)
+		}
+		if p.Len() != c.wantLen {
+			t.Errorf("%s: Len() = %d, want %d", c.name, p.Len(), c.wantLen)
+		}
+		if gets, misses, _ := p.Stats(); gets != uint64(c.n) || misses != c.wantMisses {
+			t.Errorf("%s: gets, misses = %d, %d, want %d, %d", c.name, gets, misses, c.n, c.wantMisses)
+		}
+
+		// Slots handed out must not keep references in the backing array.
+		for i, v := range p.items[len(p.items):cap(p.items)] {
+			if v != 0 {
+				t.Errorf("%s: moved slot %d = %d, want cleared", c.name, len(p.items)+i, v)
+			}
+		}
+	}
+
+	if got := counterPool().GetN(0); got != nil {
+		t.Errorf("GetN(0) = %v, want nil", got)
+	}
+}
+
+func TestPoolMaxSize(t *testing.T) {
+	cases := []struct {
+		name    string
+		maxSize int
+		puts    int
+		wantLen int
+	}{
+		{"unlimited", 0, 5, 5},
+		{"below cap", 3, 2, 2},
+		{"at cap", 3, 3, 3},
+		{"over cap", 3, 5, 3},
+	}
+	for _, c := range cases {
+		p := counterPool()
+		p.MaxSize = c.maxSize
+		for i := range c.puts {
+			p.Put(i)
+		}
+		if p.Len() != c.wantLen {
+			t.Errorf("%s: Len() = %d, want %d", c.name, p.Len(), c.wantLen)
+		}
+		if _, _, puts := p.Stats(); puts != uint64(c.puts) {
+			t.Errorf("%s: puts = %d, want %d", c.name, puts, c.puts)
+		}
+	}
+}
+
+func TestPoolReset(t *testing.T) {
+	var resets int
+	p := NewPool(func() []int { return nil })
+	p.MaxSize = 2
+	p.Reset = func(buf *[]int) {
+		resets++
+		*buf = (*buf)[:0]
+	}
+
+	p.Put([]int{1, 2, 3})
+	p.Put([]int{4})
+	p.Put([]int{5}) // dropped: the pool is full
+
+	if resets != 2 {
+		t.Fatalf("Reset called %d times, want 2 (never for dropped objects)", resets)
+	}
+	for p.Len() > 0 {
+		if buf := p.Get(); len(buf) != 0 || cap(buf) == 0 {
+			t.Fatalf("Get() = %v (cap %d), want an emptied buffer with capacity", buf, cap(buf))
+		}
+	}
+}
+
+func TestPoolPrewarm(t *testing.T) {
+	cases := []struct {
+		name    string
+		maxSize int
+		pooled  int
+		n       int
+		wantLen int
+	}{
+		{"unlimited", 0, 0, 4, 4},
+		{"capped", 3, 0, 5, 3},
+		{"already partly full", 3, 2, 5, 3},
+		{"already full", 3, 3, 5, 3},
+		{"non-positive", 0, 0, -1, 0},
+	}
+	for _, c := range cases {
+		p := counterPool()
+		p.MaxSize = c.maxSize
+		for i := range c.pooled {
+			p.Put(i)
+		}
+		p.Prewarm(c.n)
+		if p.Len() != c.wantLen {
+			t.Errorf("%s: Len() = %d, want %d", c.name, p.Len(), c.wantLen)
+		}
+	}
+
+	var nilNew Pool[int]
+	nilNew.Prewarm(3)
+	if nilNew.Len() != 0 {
+		t.Errorf("Prewarm with nil New stored %d objects, want 0", nilNew.Len())
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	p := counterPool()
+
+	p.Get() // miss
+	p.PutN([]int{1, 2, 3})
+	p.GetN(2)         // two hits
+	p.Get()           // hit
+	p.Get()           // miss
+	p.AppendN(nil, 3) // three misses
+	p.Put(4)
+	p.PutN([]int{5, 6})
+
+	gets, misses, puts := p.Stats()
+	if gets != 8 || misses != 5 || puts != 6 {
+		t.Fatalf("Stats() = %d, %d, %d, want 8, 5, 6", gets, misses, puts)
+	}
+}
+
+func TestPoolShrink(t *testing.T) {
+	p := counterPool()
+	for i := range 64 {
+		p.Put(i)
+	}
+	p.GetN(60)
+
+	p.Shrink()
+	if p.Len() != 4 || cap(p.items) != 4 {
+		t.Fatalf("after Shrink: len %d cap %d, want 4 and 4", p.Len(), cap(p.items))
+	}
+	if got, want := p.GetN(4), []int{0, 1, 2, 3}; !slices.Equal(got, want) {
+		t.Fatalf("GetN after Shrink = %v, want %v", got, want)
+	}
+
+	p.Shrink()
+	if p.items != nil {
+		t.Fatalf("Shrink on an empty pool kept a backing array of cap %d", cap(p.items))
+	}
+}
+
+func TestPoolDrain(t *testing.T) {
+	p := counterPool()
+	p.PutN([]int{1, 2, 3})
+
+	var drained []int
+	p.Drain(func(v int) { drained = append(drained, v) })
+	if want := []int{3, 2, 1}; !slices.Equal(drained, want) {
+		t.Fatalf("Drain order = %v, want %v", drained, want)
+	}
+	if p.Len() != 0 {
+		t.Fatalf("Len() after Drain = %d, want 0", p.Len())
+	}
+	if gets, _, _ := p.Stats(); gets != 0 {
+		t.Fatalf("Drain counted %d gets, want 0", gets)
+	}
+
+	called := false
+	p.Drain(func(int) { called = true })
+	if called {
+		t.Fatal("Drain called fn on an empty pool")
+	}
+}
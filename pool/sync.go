@@ -0,0 +1,41 @@
+package pool
+
+import "sync"
+
+// SyncPool is a Pool guarded by a mutex so that it is safe for concurrent use.
+//
+// Unlike sync.Pool, retained objects are never dropped by the runtime and
+// retention is controlled through the wrapped Pool's MaxSize.
+//
+// Create one with NewSyncPool; the zero value is not usable.
+type SyncPool[T any] struct {
+	mu   sync.Mutex
+	pool *Pool[T]
+}
+
+// NewSyncPool returns a SyncPool that wraps p. It panics if p or p.New is nil.
+// The caller must not use p directly afterwards.
+func NewSyncPool[T any](p *Pool[T]) *SyncPool[T] {
+	if p == nil || p.New == nil {
+		panic("pool: NewSyncPool called with nil Pool or nil New function")
+	}
+	return &SyncPool[T]{pool: p}
+}
+
+func (s *SyncPool[T]) Get() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pool.Get()
+}
+
+func (s *SyncPool[T]) Put(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pool.Put(item)
+}
+
+func (s *SyncPool[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pool.Len()
+}
@@ -0,0 +1,41 @@
+package pool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncPoolConcurrent(t *testing.T) {
+	var created atomic.Int64
+	s := NewSyncPool(NewPool(func() *int {
+		created.Add(1)
+		return new(int)
+	}))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 1000 {
+				item := s.Get()
+				*item++
+				s.Put(item)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Every object handed out was returned, so all of them are pooled.
+	if got := s.Len(); int64(got) != created.Load() {
+		t.Fatalf("Len() = %d, want %d created objects", got, created.Load())
+	}
+}
+
+func TestNewSyncPoolNilNew(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewSyncPool did not panic on a nil New function")
+		}
+	}()
+	NewSyncPool(&Pool[int]{})
+}
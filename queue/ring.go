@@ -0,0 +1,75 @@
+package queue
+
+type RingBufferMode uint8
+
+const (
+	// DropOnFull rejects new items while the buffer is full.
+	DropOnFull RingBufferMode = 0
+	// OverwriteOldest replaces the oldest item when the buffer is full.
+	OverwriteOldest RingBufferMode = 1
+)
+
+// RingBuffer is a fixed-capacity FIFO queue.
+//
+// It is not safe for concurrent use.
+type RingBuffer[T any] struct {
+	items []T
+	head  int
+	size  int
+	mode  RingBufferMode
+}
+
+func NewRingBuffer[T any](capacity int, mode RingBufferMode) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("queue: ring buffer capacity must be positive")
+	}
+	return &RingBuffer[T]{
+		items: make([]T, capacity),
+		mode:  mode,
+	}
+}
+
+// Push adds an item to the back of the buffer.
+// Returns false if the buffer is full and the mode is DropOnFull.
+func (r *RingBuffer[T]) Push(item T) bool {
+	if r.size == len(r.items) {
+		if r.mode != OverwriteOldest {
+			return false
+		}
+		r.items[r.head] = item
+		r.head = (r.head + 1) % len(r.items)
+		return true
+	}
+	r.items[(r.head+r.size)%len(r.items)] = item
+	r.size++
+	return true
+}
+
+// Pop removes and returns the item at the front of the buffer.
+// Returns false if the buffer is empty.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.size == 0 {
+		return zero, false
+	}
+	item := r.items[r.head]
+	r.items[r.head] = zero
+	r.head = (r.head + 1) % len(r.items)
+	r.size--
+	return item, true
+}
+
+func (r *RingBuffer[T]) Len() int {
+	return r.size
+}
+
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.items)
+}
+
+// Clear removes all items from the buffer.
+func (r *RingBuffer[T]) Clear() {
+	clear(r.items)
+	r.head = 0
+	r.size = 0
+}
@@ -0,0 +1,64 @@
+package queue
+
+import (
+	"slices"
+	"testing"
+)
+
+func drain(r *RingBuffer[int]) []int {
+	var out []int
+	for {
+		item, ok := r.Pop()
+		if !ok {
+			return out
+		}
+		out = append(out, item)
+	}
+}
+
+func TestRingBufferDropOnFull(t *testing.T) {
+	r := NewRingBuffer[int](3, DropOnFull)
+	for i := range 3 {
+		if !r.Push(i) {
+			t.Fatalf("Push(%d) rejected before buffer was full", i)
+		}
+	}
+	if r.Push(3) {
+		t.Fatal("Push accepted an item while full")
+	}
+
+	got := drain(r)
+	want := []int{0, 1, 2}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRingBufferOverwriteOldest(t *testing.T) {
+	r := NewRingBuffer[int](3, OverwriteOldest)
+	for i := range 5 {
+		r.Push(i)
+	}
+	if r.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", r.Len())
+	}
+
+	got := drain(r)
+	want := []int{2, 3, 4}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRingBufferWrapAround(t *testing.T) {
+	r := NewRingBuffer[int](2, DropOnFull)
+	for i := range 10 {
+		r.Push(i)
+		if item, ok := r.Pop(); !ok || item != i {
+			t.Fatalf("Pop() = %d, %v, want %d, true", item, ok, i)
+		}
+	}
+	if _, ok := r.Pop(); ok {
+		t.Fatal("Pop succeeded on empty buffer")
+	}
+}
@@ -0,0 +1,45 @@
+package stack
+
+// Stack is a generic LIFO stack backed by a growable slice.
+//
+// The zero value is an empty stack ready to use. It is not safe for concurrent use.
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+// Pop removes and returns the top item. Returns false if the stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	n := len(s.items)
+	if n == 0 {
+		return zero, false
+	}
+	item := s.items[n-1]
+	s.items[n-1] = zero
+	s.items = s.items[:n-1]
+	return item, true
+}
+
+// Peek returns the top item without removing it. Returns false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	n := len(s.items)
+	if n == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[n-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Clear removes all items, keeping the backing slice capacity for reuse.
+func (s *Stack[T]) Clear() {
+	clear(s.items)
+	s.items = s.items[:0]
+}
@@ -0,0 +1,78 @@
+package stack
+
+import "testing"
+
+type TestItem struct {
+	ID int
+}
+
+func TestStackEmptyAndClear(t *testing.T) {
+	var s Stack[TestItem]
+	if item, ok := s.Pop(); ok || item != (TestItem{}) {
+		t.Fatalf("Pop() on empty stack = %v, %v, want zero, false", item, ok)
+	}
+	if item, ok := s.Peek(); ok || item != (TestItem{}) {
+		t.Fatalf("Peek() on empty stack = %v, %v, want zero, false", item, ok)
+	}
+
+	for i := range 10 {
+		s.Push(TestItem{ID: i})
+	}
+	if item, ok := s.Peek(); !ok || item.ID != 9 {
+		t.Fatalf("Peek() = %v, %v, want {9}, true", item, ok)
+	}
+	if item, ok := s.Pop(); !ok || item.ID != 9 || s.Len() != 9 {
+		t.Fatalf("Pop() = %v, %v with Len %d, want {9}, true with Len 9", item, ok, s.Len())
+	}
+
+	capacity := cap(s.items)
+	s.Clear()
+	if s.Len() != 0 || cap(s.items) != capacity {
+		t.Fatalf("after Clear: Len %d cap %d, want 0 and %d", s.Len(), cap(s.items), capacity)
+	}
+	if _, ok := s.Pop(); ok {
+		t.Fatal("Pop succeeded after Clear")
+	}
+}
+
+func BenchmarkStackPush(b *testing.B) {
+	var s Stack[TestItem]
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		s.Push(TestItem{ID: i})
+	}
+}
+
+func BenchmarkStackPushPop(b *testing.B) {
+	var s Stack[TestItem]
+
+	// Pre-populate stack
+	for i := range 1000 {
+		s.Push(TestItem{ID: i})
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		item, _ := s.Pop()
+		s.Push(item)
+	}
+}
+
+func BenchmarkStackClearRefill(b *testing.B) {
+	var s Stack[TestItem]
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for j := range 100 {
+			s.Push(TestItem{ID: j})
+		}
+		s.Clear()
+	}
+}